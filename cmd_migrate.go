@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"go-evermos/config"
+	"go-evermos/internal/migrate"
+	"log"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// newMigrator membuat migrator untuk koneksi db
+func newMigrator(db *gorm.DB, driver string) *migrate.Migrator {
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatal("Gagal ambil koneksi database:", err)
+	}
+	m, err := migrate.New(sqlDB, driver)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return m
+}
+
+// runMigrate menjalankan subcommand: migrate [up | down [n] | status]
+func runMigrate(args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Konfigurasi tidak valid:\n", err)
+	}
+	ctx := context.Background()
+	db, err := config.Connect(ctx, cfg.DB)
+	if err != nil {
+		log.Fatal("Gagal koneksi database:", err)
+	}
+	defer config.Close(db)
+	m := newMigrator(db, cfg.DB.Driver)
+
+	cmd := "up"
+	if len(args) > 0 {
+		cmd = args[0]
+	}
+
+	switch cmd {
+	case "up":
+		done, err := m.Up(ctx)
+		for _, mig := range done {
+			fmt.Println("Diterapkan:", mig)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(done) == 0 {
+			fmt.Println("Skema sudah versi terbaru")
+		}
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				log.Fatal("Jumlah langkah rollback harus angka >= 1")
+			}
+			steps = n
+		}
+		done, err := m.Down(ctx, steps)
+		for _, mig := range done {
+			fmt.Println("Dibatalkan:", mig)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, s := range statuses {
+			applied := "belum diterapkan"
+			if s.AppliedAt != nil {
+				applied = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%-40s %s\n", s.Migration, applied)
+		}
+
+	default:
+		log.Fatal("Perintah tidak dikenal. Pakai: migrate [up | down [n] | status]")
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"go-evermos/config"
+	"go-evermos/internal/repository"
+	"go-evermos/internal/service"
+	"log"
+	"strings"
+)
+
+// runRole menjalankan subcommand: role [list <email> | grant <email> <role> | revoke <email> <role>].
+// Dipakai untuk membuat admin pertama tanpa mengubah database langsung.
+func runRole(args []string) {
+	usage := "Pakai: role [list <email> | grant <email> <role> | revoke <email> <role>]"
+	if len(args) < 2 {
+		log.Fatal(usage)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Konfigurasi tidak valid:\n", err)
+	}
+	db, err := config.Connect(context.Background(), cfg.DB)
+	if err != nil {
+		log.Fatal("Gagal koneksi database:", err)
+	}
+	defer config.Close(db)
+
+	repos := repository.NewGorm(db)
+	roles := service.NewRoleService(repos)
+
+	user, err := repos.Users.FindByEmail(strings.ToLower(strings.TrimSpace(args[1])))
+	if err != nil {
+		log.Fatal("User tidak ditemukan: ", args[1])
+	}
+
+	var current []string
+	switch {
+	case args[0] == "list" && len(args) == 2:
+		current, err = roles.Roles(user.ID)
+	case args[0] == "grant" && len(args) == 3:
+		current, err = roles.Grant(0, user.ID, args[2])
+	case args[0] == "revoke" && len(args) == 3:
+		current, err = roles.Revoke(user.ID, args[2])
+	default:
+		log.Fatal(usage)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%s: %s\n", user.Email, strings.Join(current, ", "))
+}
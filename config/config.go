@@ -1,37 +1,552 @@
 package config
 
 import (
+    "crypto/rand"
+    "encoding/hex"
+    "errors"
     "fmt"
     "log"
+    "net"
+    "net/mail"
+    "net/url"
     "os"
+    "strconv"
+    "strings"
+    "time"
 
     "github.com/joho/godotenv"
-    "gorm.io/driver/mysql"
-    "gorm.io/gorm"
+    "gopkg.in/yaml.v3"
 )
 
-var DB *gorm.DB
+// Environment aplikasi lewat env APP_ENV
+const (
+    EnvDevelopment = "development"
+    EnvProduction  = "production"
+)
+
+// Driver database yang didukung lewat env DB_DRIVER
+const (
+    DriverMySQL  = "mysql"
+    DriverSQLite = "sqlite"
+)
+
+// MinJWTSecretLength adalah panjang minimum JWT_SECRET di production (256 bit)
+const MinJWTSecretLength = 32
+
+// MaxJWTLeeway membatasi toleransi jam supaya token kedaluwarsa tidak
+// diterima terlalu lama
+const MaxJWTLeeway = 5 * time.Minute
+
+// Config adalah seluruh konfigurasi aplikasi. Dibaca sekali di main lalu
+// diteruskan ke komponen yang membutuhkan; tidak ada komponen lain yang
+// membaca env sendiri.
+type Config struct {
+    Env  string `yaml:"env"`
+    Port int    `yaml:"port"`
+    // ShutdownTimeout adalah batas waktu menunggu request yang sedang
+    // berjalan selesai saat server dimatikan
+    ShutdownTimeout time.Duration   `yaml:"shutdown_timeout"`
+    PaymentWindow   time.Duration   `yaml:"payment_window"`
+    DB              DBConfig        `yaml:"db"`
+    JWT             JWTConfig       `yaml:"jwt"`
+    Mail            MailConfig      `yaml:"mail"`
+    SMS             SMSConfig       `yaml:"sms"`
+    PasswordReset   ResetConfig     `yaml:"password_reset"`
+    Verification    VerifyConfig    `yaml:"verification"`
+    Login           LoginConfig     `yaml:"login"`
+    TwoFactor       TwoFactorConfig `yaml:"two_factor"`
+    // TrustedProxies adalah IP/CIDR reverse proxy yang boleh menentukan IP
+    // client lewat header X-Forwarded-For
+    TrustedProxies []string `yaml:"trusted_proxies"`
+}
+
+type DBConfig struct {
+    Driver string `yaml:"driver"`
+    User   string `yaml:"user"`
+    Pass   string `yaml:"pass"`
+    Host   string `yaml:"host"`
+    Port   string `yaml:"port"`
+    Name   string `yaml:"name"`
+    // Path file SQLite, atau ":memory:"
+    Path string `yaml:"path"`
+
+    // Pengaturan pool koneksi (hanya mysql; sqlite selalu satu koneksi).
+    // MaxOpenConns 0 berarti tanpa batas.
+    MaxOpenConns    int           `yaml:"max_open_conns"`
+    MaxIdleConns    int           `yaml:"max_idle_conns"`
+    ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+    ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+
+    // ConnectTimeout adalah total waktu mencoba ulang koneksi saat start
+    ConnectTimeout time.Duration `yaml:"connect_timeout"`
+}
+
+type JWTConfig struct {
+    Secret string `yaml:"secret"`
+    // TTL adalah masa berlaku access token, dibuat pendek karena access
+    // token tidak bisa dicabut; sesi diperpanjang lewat refresh token
+    TTL        time.Duration `yaml:"ttl"`
+    RefreshTTL time.Duration `yaml:"refresh_ttl"`
+
+    // Issuer dan Audience diisi ke claim iss/aud dan wajib cocok saat
+    // validasi. Service lain yang memverifikasi token harus memakai nilai
+    // yang sama.
+    Issuer   string `yaml:"issuer"`
+    Audience string `yaml:"audience"`
+    // Leeway adalah toleransi selisih jam antar server untuk exp/nbf/iat
+    Leeway time.Duration `yaml:"leeway"`
+
+    // PrivateKeyFile adalah private key PEM (RSA untuk RS256, Ed25519 untuk
+    // EdDSA). Jika diisi, token ditandatangani dengan kunci ini dan Secret
+    // tidak dipakai.
+    PrivateKeyFile string `yaml:"private_key_file"`
+    // PublicKeyFiles adalah kunci publik lama yang masih diterima selama
+    // masa rotasi kunci
+    PublicKeyFiles []string `yaml:"public_key_files"`
+}
+
+// Driver email yang didukung lewat env MAIL_DRIVER
+const (
+    MailDriverFile = "file"
+    MailDriverSMTP = "smtp"
+)
+
+type MailConfig struct {
+    Driver string `yaml:"driver"`
+    From   string `yaml:"from"`
+    // Dir adalah folder file .eml untuk driver file; kosong berarti email
+    // hanya ditulis ke log
+    Dir      string `yaml:"dir"`
+    SMTPHost string `yaml:"smtp_host"`
+    SMTPPort string `yaml:"smtp_port"`
+    SMTPUser string `yaml:"smtp_user"`
+    SMTPPass string `yaml:"smtp_pass"`
+}
+
+// Driver SMS yang didukung lewat env SMS_DRIVER
+const (
+    SMSDriverLog  = "log"
+    SMSDriverHTTP = "http"
+)
+
+type SMSConfig struct {
+    Driver string `yaml:"driver"`
+    // URL dan Token gateway SMS untuk driver http
+    URL   string `yaml:"url"`
+    Token string `yaml:"token"`
+}
+
+type ResetConfig struct {
+    // TTL adalah masa berlaku token reset password
+    TTL time.Duration `yaml:"ttl"`
+    // URL halaman reset password di frontend; token ditambahkan sebagai
+    // query ?token=
+    URL string `yaml:"url"`
+}
+
+type VerifyConfig struct {
+    // CodeTTL adalah masa berlaku kode verifikasi email/no telp
+    CodeTTL time.Duration `yaml:"code_ttl"`
+    // ResendInterval adalah jeda minimal sebelum kode baru bisa diminta
+    ResendInterval time.Duration `yaml:"resend_interval"`
+}
+
+type LoginConfig struct {
+    // MaxAttempts adalah jumlah login gagal per akun sebelum akun dikunci
+    MaxAttempts int `yaml:"max_attempts"`
+    // IPMaxAttempts adalah jumlah login gagal per IP sebelum IP dikunci
+    IPMaxAttempts int `yaml:"ip_max_attempts"`
+    // Lockout adalah lama akun/IP dikunci
+    Lockout time.Duration `yaml:"lockout"`
+}
 
-func InitDB() {
-    // Load .env file
-    err := godotenv.Load()
+type TwoFactorConfig struct {
+    // Issuer adalah nama aplikasi yang tampil di aplikasi authenticator
+    Issuer string `yaml:"issuer"`
+    // RequireAdmin mewajibkan role staf (admin, moderator, support, finance)
+    // mengaktifkan 2FA sebelum bisa memakai route admin
+    RequireAdmin bool `yaml:"require_admin"`
+}
+
+// Asymmetric bernilai true jika token ditandatangani dengan private key
+func (c JWTConfig) Asymmetric() bool {
+    return c.PrivateKeyFile != ""
+}
+
+// Default mengembalikan konfigurasi bawaan sebelum file dan env dibaca
+func Default() Config {
+    return Config{
+        Env:             EnvDevelopment,
+        Port:            3000,
+        ShutdownTimeout: 15 * time.Second,
+        PaymentWindow:   24 * time.Hour,
+        DB: DBConfig{
+            Driver:          DriverMySQL,
+            Host:            "127.0.0.1",
+            Port:            "3306",
+            Path:            "evermos.db",
+            MaxOpenConns:    25,
+            MaxIdleConns:    10,
+            ConnMaxLifetime: 30 * time.Minute,
+            ConnMaxIdleTime: 5 * time.Minute,
+            ConnectTimeout:  30 * time.Second,
+        },
+        JWT: JWTConfig{
+            TTL:        15 * time.Minute,
+            RefreshTTL: 30 * 24 * time.Hour,
+            Issuer:     "go-evermos",
+            Audience:   "go-evermos-api",
+            Leeway:     30 * time.Second,
+        },
+        Mail: MailConfig{
+            Driver:   MailDriverFile,
+            From:     "Evermos <no-reply@evermos.local>",
+            Dir:      "mail",
+            SMTPPort: "587",
+        },
+        SMS: SMSConfig{
+            Driver: SMSDriverLog,
+        },
+        PasswordReset: ResetConfig{
+            TTL: time.Hour,
+            URL: "http://localhost:3000/reset-password",
+        },
+        Verification: VerifyConfig{
+            CodeTTL:        15 * time.Minute,
+            ResendInterval: time.Minute,
+        },
+        Login: LoginConfig{
+            MaxAttempts:   10,
+            IPMaxAttempts: 50,
+            Lockout:       15 * time.Minute,
+        },
+        TwoFactor: TwoFactorConfig{
+            Issuer: "Evermos",
+        },
+    }
+}
+
+// Load membaca konfigurasi dengan urutan prioritas (yang belakang menimpa):
+// Default, file YAML (CONFIG_FILE, default config.yaml jika ada), lalu env.
+// File .env dimuat ke env tanpa menimpa env yang sudah di-set.
+func Load() (*Config, error) {
+    if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+        return nil, fmt.Errorf("gagal membaca .env: %w", err)
+    }
+
+    cfg := Default()
+
+    path, explicit := os.LookupEnv("CONFIG_FILE")
+    if !explicit {
+        path = "config.yaml"
+    }
+    if err := cfg.loadFile(path, explicit); err != nil {
+        return nil, err
+    }
+
+    if err := cfg.loadEnv(); err != nil {
+        return nil, err
+    }
+
+    if err := cfg.Validate(); err != nil {
+        return nil, err
+    }
+    return &cfg, nil
+}
+
+// loadFile membaca file YAML. File default boleh tidak ada, file yang
+// disebut lewat CONFIG_FILE wajib ada.
+func (c *Config) loadFile(path string, required bool) error {
+    data, err := os.ReadFile(path)
+    if errors.Is(err, os.ErrNotExist) && !required {
+        return nil
+    }
     if err != nil {
-        log.Println("Tidak bisa load file .env")
+        return fmt.Errorf("gagal membaca %s: %w", path, err)
+    }
+
+    if err := yaml.Unmarshal(data, c); err != nil {
+        return fmt.Errorf("format %s tidak valid: %w", path, err)
     }
+    return nil
+}
+
+func (c *Config) loadEnv() error {
+    envString("APP_ENV", &c.Env)
+    envString("DB_DRIVER", &c.DB.Driver)
+    envString("DB_USER", &c.DB.User)
+    envString("DB_PASS", &c.DB.Pass)
+    envString("DB_HOST", &c.DB.Host)
+    envString("DB_PORT", &c.DB.Port)
+    envString("DB_NAME", &c.DB.Name)
+    envString("DB_PATH", &c.DB.Path)
+    envString("JWT_SECRET", &c.JWT.Secret)
+    envString("JWT_ISSUER", &c.JWT.Issuer)
+    envString("JWT_AUDIENCE", &c.JWT.Audience)
+    envString("JWT_PRIVATE_KEY_FILE", &c.JWT.PrivateKeyFile)
+    envList("JWT_PUBLIC_KEY_FILES", &c.JWT.PublicKeyFiles)
+    envString("MAIL_DRIVER", &c.Mail.Driver)
+    envString("MAIL_FROM", &c.Mail.From)
+    envString("MAIL_DIR", &c.Mail.Dir)
+    envString("SMTP_HOST", &c.Mail.SMTPHost)
+    envString("SMTP_PORT", &c.Mail.SMTPPort)
+    envString("SMTP_USER", &c.Mail.SMTPUser)
+    envString("SMTP_PASS", &c.Mail.SMTPPass)
+    envString("SMS_DRIVER", &c.SMS.Driver)
+    envString("SMS_URL", &c.SMS.URL)
+    envString("SMS_TOKEN", &c.SMS.Token)
+    envString("PASSWORD_RESET_URL", &c.PasswordReset.URL)
+    envList("TRUSTED_PROXIES", &c.TrustedProxies)
+    envString("TWO_FACTOR_ISSUER", &c.TwoFactor.Issuer)
 
-    dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-        os.Getenv("DB_USER"),
-        os.Getenv("DB_PASS"),
-        os.Getenv("DB_HOST"),
-        os.Getenv("DB_PORT"),
-        os.Getenv("DB_NAME"),
+    return errors.Join(
+        envInt("PORT", &c.Port),
+        envDuration("SHUTDOWN_TIMEOUT", &c.ShutdownTimeout),
+        envDuration("PAYMENT_WINDOW", &c.PaymentWindow),
+        envInt("DB_MAX_OPEN_CONNS", &c.DB.MaxOpenConns),
+        envInt("DB_MAX_IDLE_CONNS", &c.DB.MaxIdleConns),
+        envDuration("DB_CONN_MAX_LIFETIME", &c.DB.ConnMaxLifetime),
+        envDuration("DB_CONN_MAX_IDLE_TIME", &c.DB.ConnMaxIdleTime),
+        envDuration("DB_CONNECT_TIMEOUT", &c.DB.ConnectTimeout),
+        envDuration("JWT_TTL", &c.JWT.TTL),
+        envDuration("JWT_REFRESH_TTL", &c.JWT.RefreshTTL),
+        envDuration("JWT_LEEWAY", &c.JWT.Leeway),
+        envDuration("PASSWORD_RESET_TTL", &c.PasswordReset.TTL),
+        envDuration("VERIFICATION_CODE_TTL", &c.Verification.CodeTTL),
+        envDuration("VERIFICATION_RESEND_INTERVAL", &c.Verification.ResendInterval),
+        envInt("LOGIN_MAX_ATTEMPTS", &c.Login.MaxAttempts),
+        envInt("LOGIN_IP_MAX_ATTEMPTS", &c.Login.IPMaxAttempts),
+        envDuration("LOGIN_LOCKOUT", &c.Login.Lockout),
+        envBool("TWO_FACTOR_REQUIRE_ADMIN", &c.TwoFactor.RequireAdmin),
     )
+}
+
+// Validate memeriksa semua nilai konfigurasi sekaligus supaya semua
+// kesalahan terlihat dalam satu kali start
+func (c *Config) Validate() error {
+    var errs []error
+
+    c.Env = strings.ToLower(c.Env)
+    if c.Env != EnvDevelopment && c.Env != EnvProduction {
+        errs = append(errs, fmt.Errorf("APP_ENV %q tidak dikenal (pilih %s atau %s)", c.Env, EnvDevelopment, EnvProduction))
+    }
+    if c.Port < 1 || c.Port > 65535 {
+        errs = append(errs, fmt.Errorf("PORT %d tidak valid", c.Port))
+    }
+    if c.ShutdownTimeout <= 0 {
+        errs = append(errs, errors.New("SHUTDOWN_TIMEOUT harus lebih dari 0"))
+    }
+    if c.PaymentWindow <= 0 {
+        errs = append(errs, errors.New("PAYMENT_WINDOW harus lebih dari 0"))
+    }
+
+    c.DB.Driver = strings.ToLower(c.DB.Driver)
+    switch c.DB.Driver {
+    case DriverMySQL:
+        if c.DB.User == "" || c.DB.Host == "" || c.DB.Name == "" {
+            errs = append(errs, errors.New("DB_USER, DB_HOST dan DB_NAME wajib diisi untuk mysql"))
+        }
+    case DriverSQLite:
+        if c.DB.Path == "" {
+            errs = append(errs, errors.New("DB_PATH wajib diisi untuk sqlite"))
+        }
+    default:
+        errs = append(errs, fmt.Errorf("DB_DRIVER %q tidak dikenal (pilih %s atau %s)", c.DB.Driver, DriverMySQL, DriverSQLite))
+    }
+    if c.DB.MaxOpenConns < 0 || c.DB.MaxIdleConns < 0 {
+        errs = append(errs, errors.New("DB_MAX_OPEN_CONNS dan DB_MAX_IDLE_CONNS tidak boleh negatif"))
+    } else if c.DB.MaxOpenConns > 0 && c.DB.MaxIdleConns > c.DB.MaxOpenConns {
+        errs = append(errs, errors.New("DB_MAX_IDLE_CONNS tidak boleh lebih besar dari DB_MAX_OPEN_CONNS"))
+    }
+    if c.DB.ConnMaxLifetime < 0 || c.DB.ConnMaxIdleTime < 0 {
+        errs = append(errs, errors.New("DB_CONN_MAX_LIFETIME dan DB_CONN_MAX_IDLE_TIME tidak boleh negatif"))
+    }
+    if c.DB.ConnectTimeout <= 0 {
+        errs = append(errs, errors.New("DB_CONNECT_TIMEOUT harus lebih dari 0"))
+    }
+
+    if c.JWT.TTL <= 0 {
+        errs = append(errs, errors.New("JWT_TTL harus lebih dari 0"))
+    }
+    if c.JWT.RefreshTTL <= c.JWT.TTL {
+        errs = append(errs, errors.New("JWT_REFRESH_TTL harus lebih lama dari JWT_TTL"))
+    }
+    if c.JWT.Issuer == "" || c.JWT.Audience == "" {
+        errs = append(errs, errors.New("JWT_ISSUER dan JWT_AUDIENCE wajib diisi"))
+    }
+    if c.JWT.Leeway < 0 || c.JWT.Leeway > MaxJWTLeeway {
+        errs = append(errs, fmt.Errorf("JWT_LEEWAY harus antara 0 dan %s", MaxJWTLeeway))
+    }
+    if c.JWT.Asymmetric() {
+        for _, f := range append([]string{c.JWT.PrivateKeyFile}, c.JWT.PublicKeyFiles...) {
+            if _, err := os.Stat(f); err != nil {
+                errs = append(errs, fmt.Errorf("file kunci JWT %s tidak bisa dibaca: %w", f, err))
+            }
+        }
+    } else if len(c.JWT.PublicKeyFiles) > 0 {
+        errs = append(errs, errors.New("JWT_PUBLIC_KEY_FILES hanya dipakai bersama JWT_PRIVATE_KEY_FILE"))
+    } else if weak := weakSecret(c.JWT.Secret); weak != "" {
+        switch {
+        case c.Production():
+            errs = append(errs, errors.New("JWT_SECRET "+weak))
+        case c.JWT.Secret == "":
+            // Development tanpa secret: pakai secret acak, token tidak
+            // berlaku lagi setelah restart
+            c.JWT.Secret = randomSecret()
+            log.Println("Peringatan: JWT_SECRET belum diisi, memakai secret acak")
+        default:
+            log.Println("Peringatan: JWT_SECRET " + weak + "; tidak boleh dipakai di production")
+        }
+    }
+
+    c.Mail.Driver = strings.ToLower(c.Mail.Driver)
+    switch c.Mail.Driver {
+    case MailDriverFile:
+        if c.Production() {
+            log.Println("Peringatan: MAIL_DRIVER=file, email tidak benar-benar dikirim")
+        }
+    case MailDriverSMTP:
+        if c.Mail.SMTPHost == "" || c.Mail.SMTPPort == "" {
+            errs = append(errs, errors.New("SMTP_HOST dan SMTP_PORT wajib diisi untuk MAIL_DRIVER=smtp"))
+        }
+    default:
+        errs = append(errs, fmt.Errorf("MAIL_DRIVER %q tidak dikenal (pilih %s atau %s)", c.Mail.Driver, MailDriverFile, MailDriverSMTP))
+    }
+    if _, err := mail.ParseAddress(c.Mail.From); err != nil {
+        errs = append(errs, fmt.Errorf("MAIL_FROM %q tidak valid", c.Mail.From))
+    }
+
+    c.SMS.Driver = strings.ToLower(c.SMS.Driver)
+    switch c.SMS.Driver {
+    case SMSDriverLog:
+        if c.Production() {
+            errs = append(errs, errors.New("SMS_DRIVER=log menulis kode verifikasi ke log dan tidak boleh dipakai di production"))
+        }
+    case SMSDriverHTTP:
+        if u, err := url.Parse(c.SMS.URL); err != nil || u.Scheme == "" || u.Host == "" {
+            errs = append(errs, fmt.Errorf("SMS_URL %q harus URL lengkap untuk SMS_DRIVER=http", c.SMS.URL))
+        }
+    default:
+        errs = append(errs, fmt.Errorf("SMS_DRIVER %q tidak dikenal (pilih %s atau %s)", c.SMS.Driver, SMSDriverLog, SMSDriverHTTP))
+    }
+
+    if c.PasswordReset.TTL <= 0 || c.PasswordReset.TTL > 24*time.Hour {
+        errs = append(errs, errors.New("PASSWORD_RESET_TTL harus antara 0 dan 24h"))
+    }
+    if u, err := url.Parse(c.PasswordReset.URL); err != nil || u.Scheme == "" || u.Host == "" {
+        errs = append(errs, fmt.Errorf("PASSWORD_RESET_URL %q harus URL lengkap", c.PasswordReset.URL))
+    }
+
+    if c.Verification.CodeTTL <= 0 || c.Verification.ResendInterval < 0 {
+        errs = append(errs, errors.New("VERIFICATION_CODE_TTL harus lebih dari 0 dan VERIFICATION_RESEND_INTERVAL tidak boleh negatif"))
+    }
+
+    if c.Login.MaxAttempts < 1 || c.Login.IPMaxAttempts < 1 || c.Login.Lockout <= 0 {
+        errs = append(errs, errors.New("LOGIN_MAX_ATTEMPTS, LOGIN_IP_MAX_ATTEMPTS dan LOGIN_LOCKOUT harus lebih dari 0"))
+    }
+    if strings.TrimSpace(c.TwoFactor.Issuer) == "" || strings.Contains(c.TwoFactor.Issuer, ":") {
+        errs = append(errs, errors.New("TWO_FACTOR_ISSUER wajib diisi dan tidak boleh mengandung \":\""))
+    }
+    if c.Production() && !c.TwoFactor.RequireAdmin {
+        log.Println("Peringatan: TWO_FACTOR_REQUIRE_ADMIN=false, admin bisa login hanya dengan password")
+    }
+
+    for _, p := range c.TrustedProxies {
+        if net.ParseIP(p) == nil {
+            if _, _, err := net.ParseCIDR(p); err != nil {
+                errs = append(errs, fmt.Errorf("TRUSTED_PROXIES %q bukan IP atau CIDR", p))
+            }
+        }
+    }
 
-    db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+    return errors.Join(errs...)
+}
+
+// Production bernilai true jika APP_ENV=production
+func (c *Config) Production() bool {
+    return c.Env == EnvProduction
+}
+
+// Addr adalah alamat listen server
+func (c *Config) Addr() string {
+    return fmt.Sprintf(":%d", c.Port)
+}
+
+// weakSecret mengembalikan alasan jika secret terlalu lemah, kosong jika aman
+func weakSecret(secret string) string {
+    switch {
+    case secret == "":
+        return "belum diisi"
+    case len(secret) < MinJWTSecretLength:
+        return fmt.Sprintf("terlalu pendek (minimal %d karakter)", MinJWTSecretLength)
+    case strings.Count(secret, secret[:1]) == len(secret):
+        return "hanya berisi satu karakter berulang"
+    }
+    return ""
+}
+
+func randomSecret() string {
+    b := make([]byte, MinJWTSecretLength)
+    rand.Read(b)
+    return hex.EncodeToString(b)
+}
+
+func envString(name string, dst *string) {
+    if v, ok := os.LookupEnv(name); ok {
+        *dst = v
+    }
+}
+
+// envList membaca daftar yang dipisah koma
+func envList(name string, dst *[]string) {
+    v, ok := os.LookupEnv(name)
+    if !ok {
+        return
+    }
+    var list []string
+    for _, item := range strings.Split(v, ",") {
+        if item = strings.TrimSpace(item); item != "" {
+            list = append(list, item)
+        }
+    }
+    *dst = list
+}
+
+func envInt(name string, dst *int) error {
+    v, ok := os.LookupEnv(name)
+    if !ok || v == "" {
+        return nil
+    }
+    n, err := strconv.Atoi(v)
+    if err != nil {
+        return fmt.Errorf("%s harus angka, bukan %q", name, v)
+    }
+    *dst = n
+    return nil
+}
+
+func envBool(name string, dst *bool) error {
+    v, ok := os.LookupEnv(name)
+    if !ok || v == "" {
+        return nil
+    }
+    b, err := strconv.ParseBool(v)
     if err != nil {
-        log.Fatal("Gagal koneksi database:", err)
+        return fmt.Errorf("%s harus true atau false, bukan %q", name, v)
     }
+    *dst = b
+    return nil
+}
 
-    DB = db
-    fmt.Println("Database connected")
+func envDuration(name string, dst *time.Duration) error {
+    v, ok := os.LookupEnv(name)
+    if !ok || v == "" {
+        return nil
+    }
+    d, err := time.ParseDuration(v)
+    if err != nil {
+        return fmt.Errorf("%s harus durasi seperti \"30m\" atau \"24h\", bukan %q", name, v)
+    }
+    *dst = d
+    return nil
 }
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+const strongSecret = "0123456789abcdef0123456789abcdef"
+
+// testConfig mengembalikan konfigurasi yang valid untuk env tertentu
+func testConfig(env string) Config {
+	c := Default()
+	c.Env = env
+	c.DB.User = "evermos"
+	c.DB.Name = "evermos"
+	c.JWT.Secret = strongSecret
+	if env == EnvProduction {
+		c.SMS.Driver = SMSDriverHTTP
+		c.SMS.URL = "https://sms.contoh.com/send"
+		c.Mail.Driver = MailDriverSMTP
+		c.Mail.SMTPHost = "smtp.contoh.com"
+		c.TwoFactor.RequireAdmin = true
+	}
+	return c
+}
+
+// assertInvalid memastikan Validate gagal dengan pesan yang memuat want
+func assertInvalid(t *testing.T, c Config, want string) {
+	t.Helper()
+
+	err := c.Validate()
+	if err == nil {
+		t.Fatalf("Validate berhasil, seharusnya gagal karena %s", want)
+	}
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("error %q tidak memuat %q", err, want)
+	}
+}
+
+func TestValidateValidConfig(t *testing.T) {
+	for _, env := range []string{EnvDevelopment, EnvProduction} {
+		c := testConfig(env)
+		if err := c.Validate(); err != nil {
+			t.Errorf("%s: %v", env, err)
+		}
+	}
+
+	c := testConfig(EnvProduction)
+	c.Env = "PRODUCTION"
+	if err := c.Validate(); err != nil || !c.Production() {
+		t.Errorf("APP_ENV tidak dinormalisasi: %v", err)
+	}
+}
+
+func TestValidateJWTSecretProduction(t *testing.T) {
+	weak := map[string]string{
+		"":                      "JWT_SECRET belum diisi",
+		"rahasia":               "JWT_SECRET terlalu pendek",
+		strings.Repeat("a", 40): "JWT_SECRET hanya berisi satu karakter berulang",
+	}
+	for secret, want := range weak {
+		c := testConfig(EnvProduction)
+		c.JWT.Secret = secret
+		assertInvalid(t, c, want)
+	}
+}
+
+func TestValidateJWTSecretDevelopment(t *testing.T) {
+	// secret kosong diganti secret acak yang cukup kuat
+	c := testConfig(EnvDevelopment)
+	c.JWT.Secret = ""
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if weak := weakSecret(c.JWT.Secret); weak != "" {
+		t.Errorf("secret acak %s", weak)
+	}
+
+	// secret lemah hanya diberi peringatan dan tidak diubah
+	c = testConfig(EnvDevelopment)
+	c.JWT.Secret = "rahasia"
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if c.JWT.Secret != "rahasia" {
+		t.Errorf("secret diubah menjadi %q", c.JWT.Secret)
+	}
+}
+
+func TestValidateJWTKeys(t *testing.T) {
+	c := testConfig(EnvProduction)
+	c.JWT.PublicKeyFiles = []string{"public.pem"}
+	assertInvalid(t, c, "JWT_PUBLIC_KEY_FILES hanya dipakai bersama JWT_PRIVATE_KEY_FILE")
+
+	c = testConfig(EnvProduction)
+	c.JWT.Secret = ""
+	c.JWT.PrivateKeyFile = "tidak-ada.pem"
+	assertInvalid(t, c, "file kunci JWT tidak-ada.pem tidak bisa dibaca")
+}
+
+func TestValidateSMS(t *testing.T) {
+	c := testConfig(EnvProduction)
+	c.SMS.Driver = SMSDriverLog
+	assertInvalid(t, c, "SMS_DRIVER=log")
+
+	c = testConfig(EnvProduction)
+	c.SMS.URL = "sms.contoh.com"
+	assertInvalid(t, c, "SMS_URL")
+
+	c = testConfig(EnvDevelopment)
+	c.SMS.Driver = "pigeon"
+	assertInvalid(t, c, "SMS_DRIVER \"pigeon\" tidak dikenal")
+
+	// sender log boleh dipakai di development
+	c = testConfig(EnvDevelopment)
+	if err := c.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidateCollectsAllErrors(t *testing.T) {
+	c := testConfig(EnvProduction)
+	c.Port = 0
+	c.JWT.Secret = ""
+	c.DB.Driver = "postgres"
+
+	err := c.Validate()
+	if err == nil {
+		t.Fatal("Validate berhasil")
+	}
+	for _, want := range []string{"PORT", "JWT_SECRET", "DB_DRIVER"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error tidak memuat %s: %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// Connect membuka database lalu memastikan bisa di-ping. Jika gagal, dicoba
+// ulang dengan jeda yang makin panjang sampai cfg.ConnectTimeout habis,
+// supaya aplikasi tidak langsung mati saat database belum siap (misal
+// container database masih start).
+func Connect(ctx context.Context, cfg DBConfig) (*gorm.DB, error) {
+	ctx, cancel := context.WithTimeout(ctx, cfg.ConnectTimeout)
+	defer cancel()
+
+	delay := time.Second
+	for attempt := 1; ; attempt++ {
+		db, err := OpenDB(cfg)
+		if err == nil {
+			if err = ping(ctx, db); err == nil {
+				return db, nil
+			}
+			Close(db)
+		}
+
+		log.Printf("Koneksi database gagal (percobaan %d): %v", attempt, err)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("database tidak bisa dihubungi setelah %d percobaan: %w", attempt, err)
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, 10*time.Second)
+	}
+}
+
+// Close menutup pool koneksi database
+func Close(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+func ping(ctx context.Context, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// OpenDB membuka koneksi database sesuai cfg.Driver.
+//
+// mysql memakai User, Pass, Host, Port dan Name. sqlite memakai Path; isi
+// ":memory:" untuk database di memori yang hilang saat aplikasi berhenti.
+func OpenDB(cfg DBConfig) (*gorm.DB, error) {
+	switch cfg.Driver {
+	case DriverMySQL:
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+			cfg.User,
+			cfg.Pass,
+			cfg.Host,
+			cfg.Port,
+			cfg.Name,
+		)
+		db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+		if err != nil {
+			return nil, err
+		}
+
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+		sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+		sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+		sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+		return db, nil
+
+	case DriverSQLite:
+		return OpenSQLite(cfg.Path)
+	}
+	return nil, fmt.Errorf("DB_DRIVER %q tidak dikenal (pilih %s atau %s)", cfg.Driver, DriverMySQL, DriverSQLite)
+}
+
+// OpenSQLite membuka database SQLite (file atau ":memory:") dengan foreign key
+// aktif seperti di MySQL.
+func OpenSQLite(path string) (*gorm.DB, error) {
+	dsn := path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite hanya mengizinkan satu penulis. Satu koneksi juga menjaga
+	// database ":memory:" tetap sama untuk semua query, karena setiap
+	// koneksi baru ke ":memory:" membuat database kosong.
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(1)
+	sqlDB.SetConnMaxLifetime(0)
+	sqlDB.SetConnMaxIdleTime(0)
+	return db, nil
+}
@@ -0,0 +1,60 @@
+// Package buildinfo menyimpan informasi build binary (commit dan waktu build).
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Diisi saat build, misalnya:
+//
+//	go build -ldflags "-X go-evermos/internal/buildinfo.Commit=$(git rev-parse HEAD) \
+//	  -X go-evermos/internal/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Commit yang kosong diambil dari info VCS yang ditanam otomatis oleh go build.
+var (
+	Commit    string
+	BuildTime string
+)
+
+type Info struct {
+	Commit     string `json:"commit"`
+	CommitTime string `json:"commit_time,omitempty"`
+	BuildTime  string `json:"build_time"`
+	// Modified bernilai true jika binary dibuild dari working tree yang
+	// punya perubahan belum di-commit
+	Modified  bool   `json:"modified"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get mengembalikan informasi build binary yang sedang berjalan
+func Get() Info {
+	info := Info{
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = s.Value
+				}
+			case "vcs.time":
+				info.CommitTime = s.Value
+			case "vcs.modified":
+				info.Modified = s.Value == "true"
+			}
+		}
+	}
+
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.BuildTime == "" {
+		info.BuildTime = "unknown"
+	}
+	return info
+}
@@ -0,0 +1,9 @@
+package dto
+
+// SensitiveFields adalah key yang tidak boleh muncul di response mana pun,
+// baik dalam snake_case maupun nama field Go (jika entity terkirim tanpa DTO).
+var SensitiveFields = []string{
+	"kata_sandi", "KataSandi", "password",
+	"totp_secret", "TOTPSecret",
+	"deleted_at", "DeletedAt",
+}
@@ -0,0 +1,153 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-evermos/internal/entities"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// contract adalah daftar key JSON yang dijanjikan ke client untuk satu
+// resource. sample harus mengisi semua field omitempty supaya key-nya muncul.
+type contract struct {
+	name   string
+	sample func() any
+	keys   []string
+}
+
+// withModel menambahkan key dari Model
+func withModel(keys ...string) []string {
+	return append([]string{"id", "created_at", "updated_at"}, keys...)
+}
+
+var contracts = []contract{
+	{"user", func() any { return NewUser(sampleUser()) },
+		withModel("nama", "email", "no_telp", "email_verified", "no_telp_verified", "two_factor_enabled", "tanggal_lahir", "jenis_kelamin", "tentang", "pekerjaan", "id_provinsi", "id_kota")},
+	{"login_attempt", func() any { return NewLoginAttempt(&entities.LoginAttempt{Model: sampleModel()}) },
+		[]string{"id", "email", "id_user", "ip", "user_agent", "hasil", "created_at"}},
+	{"store", func() any { return NewStore(sampleStore()) },
+		withModel("nama_toko", "url_foto")},
+	{"address", func() any { return NewAddress(&entities.Address{Model: sampleModel(), User: *sampleUser()}) },
+		withModel("judul_alamat", "nama_penerima", "no_telp", "detail_alamat")},
+	{"category", func() any { return NewCategory(sampleCategory()) },
+		withModel("nama_category")},
+	{"product", func() any { return NewProduct(sampleProduct()) },
+		withModel("nama_produk", "slug", "harga_reseller", "harga_konsumen", "stok", "stok_dipesan", "stok_minimum",
+			"deskripsi", "status", "alasan_ban", "id_toko", "id_category", "toko", "category", "foto")},
+	{"product_picture", func() any { return NewProductPicture(&sampleProduct().ProductPicture[0]) },
+		[]string{"id", "id_produk", "url"}},
+	{"stock_movement", func() any { return NewStockMovement(&entities.StockMovement{Model: sampleModel()}) },
+		[]string{"id", "id_produk", "tipe", "jumlah", "stok_sebelum", "stok_sesudah", "id_trx", "id_user", "catatan", "created_at"}},
+	{"trx", func() any { return NewTrx(sampleTrx()) },
+		withModel("kode_invoice", "harga_total", "method_bayar", "status_bayar", "batas_bayar", "tanggal_bayar", "alamat_pengiriman", "detail")},
+	{"trx_detail", func() any { return NewTrxDetail(&sampleTrx().TrxDetail[0]) },
+		[]string{"id", "id_trx", "id_log_produk", "id_toko", "kuantitas", "harga_total"}},
+	{"notification", func() any { return NewNotification(&entities.Notification{Model: sampleModel()}) },
+		[]string{"id", "tipe", "judul", "pesan", "id_produk", "dibaca", "dibaca_at", "created_at"}},
+	{"import_job", func() any { return NewImportJob(&entities.ImportJob{Model: sampleModel()}) },
+		withModel("id_toko", "nama_file", "format", "dry_run", "status", "total_baris", "baris_sukses", "baris_gagal", "pesan", "errors")},
+}
+
+// TestContracts memastikan JSON setiap resource berisi tepat key yang
+// tercatat di contracts dan tidak memuat SensitiveFields di objek bertingkat
+// mana pun, supaya perubahan bentuk response yang tidak disengaja (field
+// entity baru ikut terkirim, key berganti nama) langsung ketahuan.
+func TestContracts(t *testing.T) {
+	for _, c := range contracts {
+		t.Run(c.name, func(t *testing.T) {
+			data, err := json.Marshal(c.sample())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(data, &fields); err != nil {
+				t.Fatal(err)
+			}
+			for _, path := range sensitivePaths(data, c.name) {
+				t.Errorf("%s: field sensitif terkirim", path)
+			}
+
+			got := slices.Sorted(maps.Keys(fields))
+			want := slices.Sorted(slices.Values(c.keys))
+			if !slices.Equal(got, want) {
+				t.Errorf("key JSON [%s], seharusnya [%s]", strings.Join(got, " "), strings.Join(want, " "))
+			}
+		})
+	}
+}
+
+// sensitivePaths mencari SensitiveFields di seluruh dokumen JSON dan
+// mengembalikan lokasinya (mis. "product.toko.kata_sandi")
+func sensitivePaths(data []byte, prefix string) []string {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil
+	}
+
+	var paths []string
+	var walk func(v any, path string)
+	walk = func(v any, path string) {
+		switch v := v.(type) {
+		case map[string]any:
+			for k, child := range v {
+				if slices.Contains(SensitiveFields, k) {
+					paths = append(paths, path+"."+k)
+				}
+				walk(child, path+"."+k)
+			}
+		case []any:
+			for i, child := range v {
+				walk(child, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	}
+	walk(doc, prefix)
+
+	slices.Sort(paths)
+	return paths
+}
+
+func sampleModel() entities.Model {
+	now := time.Now()
+	return entities.Model{ID: 1, CreatedAt: now, UpdatedAt: now, DeletedAt: gorm.DeletedAt{Time: now, Valid: true}}
+}
+
+// Sample sengaja mengisi field sensitif supaya TestContracts gagal jika mapper
+// meneruskannya ke DTO.
+func sampleUser() *entities.User {
+	secret := "secret"
+	return &entities.User{Model: sampleModel(), KataSandi: "hash", TOTPSecret: &secret}
+}
+
+func sampleStore() *entities.Store {
+	return &entities.Store{Model: sampleModel(), IDUser: 1}
+}
+
+func sampleCategory() *entities.Category {
+	return &entities.Category{Model: sampleModel()}
+}
+
+func sampleProduct() *entities.Product {
+	alasan := "melanggar aturan"
+	return &entities.Product{
+		Model:          sampleModel(),
+		AlasanBan:      &alasan,
+		Store:          *sampleStore(),
+		Category:       *sampleCategory(),
+		ProductPicture: []entities.ProductPicture{{Model: sampleModel()}},
+	}
+}
+
+func sampleTrx() *entities.Trx {
+	return &entities.Trx{
+		Model:     sampleModel(),
+		User:      *sampleUser(),
+		TrxDetail: []entities.TrxDetail{{Model: sampleModel(), Store: *sampleStore()}},
+	}
+}
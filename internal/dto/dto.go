@@ -0,0 +1,30 @@
+// Package dto berisi bentuk JSON response API. Handler tidak mengirim entity
+// GORM secara langsung: field internal (soft delete, relasi yang tidak
+// di-preload) tidak ikut terkirim dan nama key tetap stabil walau entity berubah.
+package dto
+
+import (
+	"go-evermos/internal/entities"
+	"time"
+)
+
+// Model adalah field dasar yang dimiliki semua resource
+type Model struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func model(m entities.Model) Model {
+	return Model{ID: m.ID, CreatedAt: m.CreatedAt, UpdatedAt: m.UpdatedAt}
+}
+
+// list memetakan slice entity ke slice DTO. Hasilnya tidak pernah nil
+// supaya JSON-nya [] dan bukan null.
+func list[E, D any](items []E, fn func(*E) D) []D {
+	result := make([]D, 0, len(items))
+	for i := range items {
+		result = append(result, fn(&items[i]))
+	}
+	return result
+}
@@ -0,0 +1,37 @@
+package dto
+
+import "go-evermos/internal/entities"
+
+type ImportJob struct {
+	Model
+	IDToko      uint                      `json:"id_toko"`
+	NamaFile    string                    `json:"nama_file"`
+	Format      string                    `json:"format"`
+	DryRun      bool                      `json:"dry_run"`
+	Status      string                    `json:"status"`
+	TotalBaris  int                       `json:"total_baris"`
+	BarisSukses int                       `json:"baris_sukses"`
+	BarisGagal  int                       `json:"baris_gagal"`
+	Pesan       *string                   `json:"pesan"`
+	Errors      []entities.ImportRowError `json:"errors"`
+}
+
+func NewImportJob(j *entities.ImportJob) ImportJob {
+	errs := j.Errors
+	if errs == nil {
+		errs = []entities.ImportRowError{}
+	}
+	return ImportJob{
+		Model:       model(j.Model),
+		IDToko:      j.IDToko,
+		NamaFile:    j.NamaFile,
+		Format:      j.Format,
+		DryRun:      j.DryRun,
+		Status:      j.Status,
+		TotalBaris:  j.TotalBaris,
+		BarisSukses: j.BarisSukses,
+		BarisGagal:  j.BarisGagal,
+		Pesan:       j.Pesan,
+		Errors:      errs,
+	}
+}
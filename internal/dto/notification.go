@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"go-evermos/internal/entities"
+	"time"
+)
+
+type Notification struct {
+	ID        uint       `json:"id"`
+	Tipe      string     `json:"tipe"`
+	Judul     string     `json:"judul"`
+	Pesan     string     `json:"pesan"`
+	IDProduk  *uint      `json:"id_produk"`
+	Dibaca    bool       `json:"dibaca"`
+	DibacaAt  *time.Time `json:"dibaca_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func NewNotification(n *entities.Notification) Notification {
+	return Notification{
+		ID:        n.ID,
+		Tipe:      n.Tipe,
+		Judul:     n.Judul,
+		Pesan:     n.Pesan,
+		IDProduk:  n.IDProduk,
+		Dibaca:    n.DibacaAt != nil,
+		DibacaAt:  n.DibacaAt,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+func NewNotifications(items []entities.Notification) []Notification {
+	return list(items, NewNotification)
+}
@@ -0,0 +1,117 @@
+package dto
+
+import (
+	"go-evermos/internal/entities"
+	"time"
+)
+
+type Category struct {
+	Model
+	NamaCategory string `json:"nama_category"`
+}
+
+func NewCategory(c *entities.Category) Category {
+	return Category{Model: model(c.Model), NamaCategory: c.NamaCategory}
+}
+
+func NewCategories(items []entities.Category) []Category {
+	return list(items, NewCategory)
+}
+
+type ProductPicture struct {
+	ID       uint   `json:"id"`
+	IDProduk uint   `json:"id_produk"`
+	Url      string `json:"url"`
+}
+
+func NewProductPicture(p *entities.ProductPicture) ProductPicture {
+	return ProductPicture{ID: p.ID, IDProduk: p.IDProduk, Url: p.Url}
+}
+
+// Product menampilkan stok tersedia (Stok) dan stok yang sedang dipesan
+// transaksi belum bayar (StokDipesan) secara terpisah. Toko, kategori dan
+// foto hanya ada jika relasinya di-preload.
+type Product struct {
+	Model
+	NamaProduk    string           `json:"nama_produk"`
+	Slug          string           `json:"slug"`
+	HargaReseller string           `json:"harga_reseller"`
+	HargaKonsumen string           `json:"harga_konsumen"`
+	Stok          int              `json:"stok"`
+	StokDipesan   int              `json:"stok_dipesan"`
+	StokMinimum   int              `json:"stok_minimum"`
+	Deskripsi     *string          `json:"deskripsi"`
+	Status        string           `json:"status"`
+	AlasanBan     *string          `json:"alasan_ban,omitempty"`
+	IDToko        uint             `json:"id_toko"`
+	IDCategory    uint             `json:"id_category"`
+	Toko          *Store           `json:"toko,omitempty"`
+	Category      *Category        `json:"category,omitempty"`
+	Foto          []ProductPicture `json:"foto,omitempty"`
+}
+
+func NewProduct(p *entities.Product) Product {
+	result := Product{
+		Model:         model(p.Model),
+		NamaProduk:    p.NamaProduk,
+		Slug:          p.Slug,
+		HargaReseller: p.HargaReseller,
+		HargaKonsumen: p.HargaKonsumen,
+		Stok:          p.Stok,
+		StokDipesan:   p.StokDipesan,
+		StokMinimum:   p.StokMinimum,
+		Deskripsi:     p.Deskripsi,
+		Status:        p.Status,
+		AlasanBan:     p.AlasanBan,
+		IDToko:        p.IDToko,
+		IDCategory:    p.IDCategory,
+	}
+	if p.Store.ID != 0 {
+		toko := NewStore(&p.Store)
+		result.Toko = &toko
+	}
+	if p.Category.ID != 0 {
+		category := NewCategory(&p.Category)
+		result.Category = &category
+	}
+	if len(p.ProductPicture) > 0 {
+		result.Foto = list(p.ProductPicture, NewProductPicture)
+	}
+	return result
+}
+
+func NewProducts(items []entities.Product) []Product {
+	return list(items, NewProduct)
+}
+
+type StockMovement struct {
+	ID          uint      `json:"id"`
+	IDProduk    uint      `json:"id_produk"`
+	Tipe        string    `json:"tipe"`
+	Jumlah      int       `json:"jumlah"`
+	StokSebelum int       `json:"stok_sebelum"`
+	StokSesudah int       `json:"stok_sesudah"`
+	IDTrx       *uint     `json:"id_trx"`
+	IDUser      *uint     `json:"id_user"`
+	Catatan     *string   `json:"catatan"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewStockMovement(m *entities.StockMovement) StockMovement {
+	return StockMovement{
+		ID:          m.ID,
+		IDProduk:    m.IDProduk,
+		Tipe:        m.Tipe,
+		Jumlah:      m.Jumlah,
+		StokSebelum: m.StokSebelum,
+		StokSesudah: m.StokSesudah,
+		IDTrx:       m.IDTrx,
+		IDUser:      m.IDUser,
+		Catatan:     m.Catatan,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+func NewStockMovements(items []entities.StockMovement) []StockMovement {
+	return list(items, NewStockMovement)
+}
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"go-evermos/internal/entities"
+	"time"
+)
+
+type Trx struct {
+	Model
+	KodeInvoice      string      `json:"kode_invoice"`
+	HargaTotal       int         `json:"harga_total"`
+	MethodBayar      string      `json:"method_bayar"`
+	StatusBayar      string      `json:"status_bayar"`
+	BatasBayar       *time.Time  `json:"batas_bayar"`
+	TanggalBayar     *time.Time  `json:"tanggal_bayar"`
+	AlamatPengiriman uint        `json:"alamat_pengiriman"`
+	Detail           []TrxDetail `json:"detail,omitempty"`
+}
+
+func NewTrx(t *entities.Trx) Trx {
+	result := Trx{
+		Model:            model(t.Model),
+		KodeInvoice:      t.KodeInvoice,
+		HargaTotal:       t.HargaTotal,
+		MethodBayar:      t.MethodBayar,
+		StatusBayar:      t.StatusBayar,
+		BatasBayar:       t.BatasBayar,
+		TanggalBayar:     t.TanggalBayar,
+		AlamatPengiriman: t.AlamatPengiriman,
+	}
+	if len(t.TrxDetail) > 0 {
+		result.Detail = NewTrxDetails(t.TrxDetail)
+	}
+	return result
+}
+
+func NewTrxs(items []entities.Trx) []Trx {
+	return list(items, NewTrx)
+}
+
+type TrxDetail struct {
+	ID          uint `json:"id"`
+	IDTrx       uint `json:"id_trx"`
+	IDLogProduk uint `json:"id_log_produk"`
+	IDToko      uint `json:"id_toko"`
+	Kuantitas   int  `json:"kuantitas"`
+	HargaTotal  int  `json:"harga_total"`
+}
+
+func NewTrxDetail(d *entities.TrxDetail) TrxDetail {
+	return TrxDetail{
+		ID:          d.ID,
+		IDTrx:       d.IDTrx,
+		IDLogProduk: d.IDLogProduk,
+		IDToko:      d.IDToko,
+		Kuantitas:   d.Kuantitas,
+		HargaTotal:  d.HargaTotal,
+	}
+}
+
+func NewTrxDetails(items []entities.TrxDetail) []TrxDetail {
+	return list(items, NewTrxDetail)
+}
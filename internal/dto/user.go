@@ -0,0 +1,103 @@
+package dto
+
+import (
+	"go-evermos/internal/entities"
+	"time"
+)
+
+type User struct {
+	Model
+	Nama           string  `json:"nama"`
+	Email          string  `json:"email"`
+	NoTelp         string  `json:"no_telp"`
+	EmailVerified  bool    `json:"email_verified"`
+	NoTelpVerified bool    `json:"no_telp_verified"`
+	TwoFactor      bool    `json:"two_factor_enabled"`
+	TanggalLahir   string  `json:"tanggal_lahir"`
+	JenisKelamin   string  `json:"jenis_kelamin"`
+	Tentang        *string `json:"tentang"`
+	Pekerjaan      string  `json:"pekerjaan"`
+	IDProvinsi     string  `json:"id_provinsi"`
+	IDKota         string  `json:"id_kota"`
+}
+
+// NewUser memetakan profil user. Hash kata sandi dan flag admin tidak ikut.
+func NewUser(u *entities.User) User {
+	return User{
+		Model:          model(u.Model),
+		Nama:           u.Nama,
+		Email:          u.Email,
+		NoTelp:         u.Notelp,
+		EmailVerified:  u.EmailVerifiedAt != nil,
+		NoTelpVerified: u.PhoneVerifiedAt != nil,
+		TwoFactor:      u.TOTPEnabledAt != nil,
+		TanggalLahir:   u.TanggalLahir.Format("2006-01-02"),
+		JenisKelamin:   u.JenisKelamin,
+		Tentang:        u.Tentang,
+		Pekerjaan:      u.Pekerjaan,
+		IDProvinsi:     u.IDProvinsi,
+		IDKota:         u.IDKota,
+	}
+}
+
+type Store struct {
+	Model
+	NamaToko *string `json:"nama_toko"`
+	UrlFoto  *string `json:"url_foto"`
+}
+
+func NewStore(s *entities.Store) Store {
+	return Store{
+		Model:    model(s.Model),
+		NamaToko: s.NamaToko,
+		UrlFoto:  s.UrlFoto,
+	}
+}
+
+type Address struct {
+	Model
+	JudulAlamat  string `json:"judul_alamat"`
+	NamaPenerima string `json:"nama_penerima"`
+	NoTelp       string `json:"no_telp"`
+	DetailAlamat string `json:"detail_alamat"`
+}
+
+func NewAddress(a *entities.Address) Address {
+	return Address{
+		Model:        model(a.Model),
+		JudulAlamat:  a.JudulAlamat,
+		NamaPenerima: a.NamaPenerima,
+		NoTelp:       a.NoTelp,
+		DetailAlamat: a.DetailAlamat,
+	}
+}
+
+func NewAddresses(items []entities.Address) []Address {
+	return list(items, NewAddress)
+}
+
+type LoginAttempt struct {
+	ID        uint      `json:"id"`
+	Email     string    `json:"email"`
+	IDUser    *uint     `json:"id_user"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	Outcome   string    `json:"hasil"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewLoginAttempt(a *entities.LoginAttempt) LoginAttempt {
+	return LoginAttempt{
+		ID:        a.ID,
+		Email:     a.Email,
+		IDUser:    a.IDUser,
+		IP:        a.IP,
+		UserAgent: a.UserAgent,
+		Outcome:   a.Outcome,
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+func NewLoginAttempts(items []entities.LoginAttempt) []LoginAttempt {
+	return list(items, NewLoginAttempt)
+}
@@ -0,0 +1,35 @@
+package entities
+
+// Status proses import produk massal
+const (
+	ImportStatusPending    = "pending"
+	ImportStatusProcessing = "processing"
+	ImportStatusCompleted  = "completed"
+	ImportStatusFailed     = "failed"
+)
+
+// ImportRowError mencatat kesalahan validasi per baris file import
+type ImportRowError struct {
+	Baris int    `json:"baris"`
+	Kolom string `json:"kolom,omitempty"`
+	Pesan string `json:"pesan"`
+}
+
+type ImportJob struct {
+	Model
+	IDToko      uint             `gorm:"not null;index"`
+	IDUser      uint             `gorm:"not null"`
+	NamaFile    string           `gorm:"size:255;not null"`
+	Format      string           `gorm:"size:10;not null"`
+	DryRun      bool             `gorm:"type:boolean;default:false"`
+	Status      string           `gorm:"size:20;not null;default:pending"`
+	TotalBaris  int              `gorm:"not null;default:0"`
+	BarisSukses int              `gorm:"not null;default:0"`
+	BarisGagal  int              `gorm:"not null;default:0"`
+	Pesan       *string          `gorm:"type:text;default:null"`
+	Errors      []ImportRowError `gorm:"type:text;serializer:json"`
+}
+
+func (ImportJob) TableName() string {
+	return "ImportJob"
+}
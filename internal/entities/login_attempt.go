@@ -0,0 +1,31 @@
+package entities
+
+// Hasil percobaan login
+const (
+	LoginSuccess = "success"
+	LoginFailed  = "failed"
+	// LoginLocked berarti percobaan ditolak sebelum password diperiksa
+	// karena akun atau IP sedang dikunci
+	LoginLocked = "locked"
+	// LoginBanned berarti password benar tetapi akun diblokir
+	LoginBanned = "banned"
+	// LoginTwoFactor berarti password benar dan login menunggu kode 2FA
+	LoginTwoFactor = "2fa"
+)
+
+// LoginAttempt mencatat setiap percobaan login untuk proteksi brute force
+// dan audit keamanan. Email berisi email akun (huruf kecil); jika akun tidak
+// dikenal, berisi email atau no telp yang diketik. IDUser hanya terisi jika
+// akun dikenal.
+type LoginAttempt struct {
+	Model
+	Email     string `gorm:"size:255;not null;index:idx_LoginAttempt_email_created"`
+	IDUser    *uint  `gorm:"index"`
+	IP        string `gorm:"column:ip;size:45;not null;index:idx_LoginAttempt_ip_created"`
+	UserAgent string `gorm:"size:255;not null"`
+	Outcome   string `gorm:"size:10;not null"`
+}
+
+func (LoginAttempt) TableName() string {
+	return "LoginAttempt"
+}
@@ -0,0 +1,19 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Model adalah kolom dasar semua tabel: primary key, waktu dibuat/diubah
+// (selalu terisi), dan soft delete. Pengganti gorm.Model yang sebelumnya
+// ditimpa ulang ID/CreatedAt/UpdatedAt di setiap entity.
+//
+// Entity tidak dikirim langsung sebagai response API; pakai DTO di package dto.
+type Model struct {
+	ID        uint           `gorm:"primaryKey"`
+	CreatedAt time.Time      `gorm:"not null"`
+	UpdatedAt time.Time      `gorm:"not null"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
@@ -0,0 +1,35 @@
+package entities
+
+import "time"
+
+// Jenis notifikasi in-app
+const (
+	NotificationLowStock    = "low_stock"
+	NotificationBackInStock = "back_in_stock"
+)
+
+type Notification struct {
+	Model
+	IDUser   uint   `gorm:"not null;index"`
+	Tipe     string `gorm:"size:30;not null"`
+	Judul    string `gorm:"size:255;not null"`
+	Pesan    string `gorm:"type:text;not null"`
+	IDProduk *uint  `gorm:"default:null"`
+	DibacaAt *time.Time
+}
+
+func (Notification) TableName() string {
+	return "Notifikasi"
+}
+
+// StockSubscription adalah permintaan pembeli untuk diberi tahu saat produk
+// yang habis kembali tersedia. Baris dihapus setelah notifikasi dikirim.
+type StockSubscription struct {
+	Model
+	IDUser   uint `gorm:"not null;uniqueIndex:idx_langganan_user_produk"`
+	IDProduk uint `gorm:"not null;uniqueIndex:idx_langganan_user_produk"`
+}
+
+func (StockSubscription) TableName() string {
+	return "LanggananStok"
+}
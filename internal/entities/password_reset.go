@@ -0,0 +1,30 @@
+package entities
+
+import "time"
+
+// PasswordReset adalah token reset password yang dikirim lewat email. Hanya
+// hash SHA-256 yang disimpan dan token hanya bisa dipakai sekali.
+type PasswordReset struct {
+	Model
+	IDUser    uint      `gorm:"not null;index"`
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+}
+
+func (PasswordReset) TableName() string {
+	return "PasswordReset"
+}
+
+// PasswordResetRequest mencatat setiap permintaan lupa password, terdaftar
+// atau tidak, untuk membatasi permintaan per email dan per IP. Email disimpan
+// dalam huruf kecil.
+type PasswordResetRequest struct {
+	Model
+	Email string `gorm:"size:255;not null;index:idx_PasswordResetRequest_email_created"`
+	IP    string `gorm:"column:ip;size:45;not null;index:idx_PasswordResetRequest_ip_created"`
+}
+
+func (PasswordResetRequest) TableName() string {
+	return "PasswordResetRequest"
+}
@@ -1,24 +1,27 @@
 package entities
 
-import (
-	"time"
-
-	"gorm.io/gorm"
+// Status publikasi produk. Hanya produk active yang tampil di katalog publik.
+const (
+	ProductStatusDraft    = "draft"
+	ProductStatusActive   = "active"
+	ProductStatusArchived = "archived"
+	ProductStatusBanned   = "banned"
 )
 
 type Product struct {
-	gorm.Model
-	ID             uint    `gorm:"primaryKey"`
-	NamaProduk     string  `gorm:"size:255;not null"`
-	Slug           string  `gorm:"size:255;not null"`
-	HargaReseller  string  `gorm:"size:255;not null"`
-	HargaKonsumen  string  `gorm:"size:255;not null"`
-	Stok           int     `gorm:"not null"`
-	Deskripsi      *string `gorm:"type:text;default:null"`
-	IDToko         uint    `gorm:"not null"`
-	IDCategory     uint    `gorm:"not null"`
-	CreatedAt      *time.Time
-	UpdatedAt      *time.Time
+	Model
+	NamaProduk     string           `gorm:"size:255;not null"`
+	Slug           string           `gorm:"size:255;not null"`
+	HargaReseller  string           `gorm:"size:255;not null"`
+	HargaKonsumen  string           `gorm:"size:255;not null"`
+	Stok           int              `gorm:"not null"`
+	StokDipesan    int              `gorm:"not null;default:0"`
+	StokMinimum    int              `gorm:"not null;default:0"`
+	Deskripsi      *string          `gorm:"type:text;default:null"`
+	Status         string           `gorm:"size:20;not null;default:active;index"`
+	AlasanBan      *string          `gorm:"type:text;default:null"`
+	IDToko         uint             `gorm:"not null"`
+	IDCategory     uint             `gorm:"not null"`
 	Store          Store            `gorm:"foreignKey:IDToko"`
 	Category       Category         `gorm:"foreignKey:IDCategory"`
 	ProductPicture []ProductPicture `gorm:"foreignKey:IDProduk"`
@@ -26,4 +29,4 @@ type Product struct {
 
 func (Product) TableName() string {
 	return "Produk"
-}
\ No newline at end of file
+}
@@ -1,24 +1,15 @@
 package entities
 
-import (
-	"time"
-
-	"gorm.io/gorm"
-)
-
 type ProductLog struct {
-	gorm.Model
-	ID            uint    `gorm:"primaryKey"`
-	IDProduk      uint    `gorm:"not null"`
-	NamaProduk    string  `gorm:"size:255;not null"`
-	Slug          string  `gorm:"size:255;not null"`
-	HargaReseller string  `gorm:"size:255;not null"`
-	HargaKonsumen string  `gorm:"size:255;not null"`
-	Deskripsi     *string `gorm:"type:text;default:null"`
-	IDToko        uint    `gorm:"not null"`
-	IDCategory    uint    `gorm:"not null"`
-	CreatedAt     *time.Time
-	UpdatedAt     *time.Time
+	Model
+	IDProduk      uint     `gorm:"not null"`
+	NamaProduk    string   `gorm:"size:255;not null"`
+	Slug          string   `gorm:"size:255;not null"`
+	HargaReseller string   `gorm:"size:255;not null"`
+	HargaKonsumen string   `gorm:"size:255;not null"`
+	Deskripsi     *string  `gorm:"type:text;default:null"`
+	IDToko        uint     `gorm:"not null"`
+	IDCategory    uint     `gorm:"not null"`
 	Store         Store    `gorm:"foreignKey:IDToko"`
 	Category      Category `gorm:"foreignKey:IDCategory"`
 	Product       Product  `gorm:"foreignKey:IDProduk"`
@@ -26,4 +17,4 @@ type ProductLog struct {
 
 func (ProductLog) TableName() string {
 	return "ProdukLog"
-}
\ No newline at end of file
+}
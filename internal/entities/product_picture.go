@@ -1,20 +1,11 @@
 package entities
 
-import (
-	"time"
-
-	"gorm.io/gorm"
-)
-
 type ProductPicture struct {
-	gorm.Model
-	ID        uint   `gorm:"primaryKey"`
-	IDProduk  uint   `gorm:"not null"`
-	Url       string `gorm:"size:255;not null"`
-	CreatedAt *time.Time
-	UpdatedAt *time.Time
+	Model
+	IDProduk uint   `gorm:"not null"`
+	Url      string `gorm:"size:255;not null"`
 }
 
 func (ProductPicture) TableName() string {
 	return "FotoProduk"
-}
\ No newline at end of file
+}
@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+// RefreshToken adalah refresh token yang pernah diterbitkan. Token aslinya
+// tidak disimpan, hanya hash SHA-256. Semua token hasil rotasi dari satu login
+// berbagi Family yang sama, sehingga satu sesi bisa dicabut sekaligus.
+type RefreshToken struct {
+	Model
+	IDUser    uint      `gorm:"not null;index"`
+	Family    string    `gorm:"size:64;not null;index"`
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	// RotatedAt terisi saat token sudah ditukar dengan token baru. Token
+	// yang sudah dirotasi lalu dipakai lagi dianggap dicuri.
+	RotatedAt *time.Time
+	RevokedAt *time.Time
+}
+
+func (RefreshToken) TableName() string {
+	return "RefreshToken"
+}
@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+// RevokedToken mencatat access token (claim jti) yang dicabut sebelum masa
+// berlakunya habis. Baris boleh dihapus setelah ExpiresAt lewat.
+type RevokedToken struct {
+	Model
+	JTI       string    `gorm:"column:jti;size:64;not null;uniqueIndex"`
+	IDUser    uint      `gorm:"not null;index"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+}
+
+func (RevokedToken) TableName() string {
+	return "RevokedToken"
+}
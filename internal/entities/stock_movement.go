@@ -0,0 +1,28 @@
+package entities
+
+// Jenis mutasi stok yang dicatat di ledger
+const (
+	StockMovementSale          = "sale"
+	StockMovementCancelRestock = "cancel_restock"
+	StockMovementAdjustment    = "adjustment"
+	StockMovementImport        = "import"
+	StockMovementReturn        = "return"
+)
+
+// StockMovement adalah satu baris ledger stok. Jumlah bernilai negatif untuk
+// stok keluar, sehingga total Jumlah per produk harus sama dengan Product.Stok.
+type StockMovement struct {
+	Model
+	IDProduk    uint    `gorm:"not null;index"`
+	Tipe        string  `gorm:"size:20;not null"`
+	Jumlah      int     `gorm:"not null"`
+	StokSebelum int     `gorm:"not null"`
+	StokSesudah int     `gorm:"not null"`
+	IDTrx       *uint   `gorm:"default:null;index"`
+	IDUser      *uint   `gorm:"default:null"`
+	Catatan     *string `gorm:"type:text;default:null"`
+}
+
+func (StockMovement) TableName() string {
+	return "MutasiStok"
+}
@@ -1,21 +1,25 @@
 package entities
 
-import (
-	"time"
+import "time"
 
-	"gorm.io/gorm"
+// Status pembayaran transaksi
+const (
+	TrxStatusPending   = "pending"
+	TrxStatusPaid      = "paid"
+	TrxStatusExpired   = "expired"
+	TrxStatusCancelled = "cancelled"
 )
 
 type Trx struct {
-	gorm.Model
-	ID               uint   `gorm:"primaryKey"`
+	Model
 	IDUser           uint   `gorm:"not null"`
 	AlamatPengiriman uint   `gorm:"not null"`
 	HargaTotal       int    `gorm:"not null"`
 	KodeInvoice      string `gorm:"size:255;not null"`
 	MethodBayar      string `gorm:"size:255;not null"`
-	CreatedAt        *time.Time
-	UpdatedAt        *time.Time
+	StatusBayar      string `gorm:"size:20;not null;default:paid;index"`
+	BatasBayar       *time.Time
+	TanggalBayar     *time.Time
 	Address          Address     `gorm:"foreignKey:AlamatPengiriman"`
 	User             User        `gorm:"foreignKey:IDUser"`
 	TrxDetail        []TrxDetail `gorm:"foreignKey:IDTrx"`
@@ -23,4 +27,4 @@ type Trx struct {
 
 func (Trx) TableName() string {
 	return "Trx"
-}
\ No newline at end of file
+}
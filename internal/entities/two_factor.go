@@ -0,0 +1,33 @@
+package entities
+
+import "time"
+
+// LoginChallenge diterbitkan saat password benar tetapi user memakai 2FA.
+// Token challenge ditukar dengan token login setelah kode 2FA benar; hanya
+// hash SHA-256 yang disimpan.
+type LoginChallenge struct {
+	Model
+	IDUser    uint      `gorm:"not null;index"`
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	// Attempts menghitung kode 2FA yang salah untuk challenge ini
+	Attempts int `gorm:"not null;default:0"`
+	UsedAt   *time.Time
+}
+
+func (LoginChallenge) TableName() string {
+	return "LoginChallenge"
+}
+
+// RecoveryCode adalah kode cadangan 2FA sekali pakai untuk login jika
+// aplikasi authenticator hilang. Hanya hash SHA-256 yang disimpan.
+type RecoveryCode struct {
+	Model
+	IDUser   uint   `gorm:"not null;index"`
+	CodeHash string `gorm:"size:64;not null"`
+	UsedAt   *time.Time
+}
+
+func (RecoveryCode) TableName() string {
+	return "RecoveryCode"
+}
@@ -1,13 +1,9 @@
 package entities
 
-import (
-	"time"
-	"gorm.io/gorm"
-)
+import "time"
 
 type User struct {
-	gorm.Model
-	ID           uint      `gorm:"primaryKey"`
+	Model
 	Nama         string    `gorm:"size:255;not null"`
 	KataSandi    string    `gorm:"size:255;not null"`
 	Notelp       string    `gorm:"size:255;not null;index:idx_notelp,unique"`
@@ -18,11 +14,26 @@ type User struct {
 	Email        string    `gorm:"size:255;not null;index:idx_email,unique"`
 	IDProvinsi   string    `gorm:"size:255;not null"`
 	IDKota       string    `gorm:"size:255;not null"`
-	IsAdmin      bool      `gorm:"type:boolean;default:false"`
-	CreatedAt    *time.Time
-	UpdatedAt    *time.Time
+	// EmailVerifiedAt dan PhoneVerifiedAt terisi setelah kode verifikasi
+	// dikonfirmasi. PhoneVerifiedAt dikosongkan lagi jika no telp diganti.
+	EmailVerifiedAt *time.Time
+	PhoneVerifiedAt *time.Time
+	// BannedAt terisi jika akun diblokir admin
+	BannedAt  *time.Time
+	AlasanBan *string `gorm:"type:text;default:null"`
+	// TokenVersion dinaikkan untuk mencabut semua token user sekaligus
+	// (logout semua perangkat, ganti password, blokir). Token yang membawa
+	// versi lama ditolak.
+	TokenVersion uint `gorm:"not null;default:0"`
+	// TOTPSecret terisi sejak 2FA disiapkan, tetapi 2FA baru aktif setelah
+	// TOTPEnabledAt terisi (kode pertama dikonfirmasi)
+	TOTPSecret    *string    `gorm:"column:totp_secret;size:64;default:null"`
+	TOTPEnabledAt *time.Time `gorm:"column:totp_enabled_at"`
+	// TOTPLastStep adalah periode kode TOTP terakhir yang dipakai, supaya
+	// kode yang sama tidak bisa dipakai dua kali
+	TOTPLastStep int64 `gorm:"column:totp_last_step;not null;default:0"`
 }
 
 func (User) TableName() string {
 	return "Users"
-}
\ No newline at end of file
+}
@@ -0,0 +1,16 @@
+package entities
+
+// UserRole adalah role yang diberikan admin ke user. Role buyer dan seller
+// tidak disimpan karena diturunkan dari akun dan kepemilikan toko.
+type UserRole struct {
+	Model
+	IDUser uint   `gorm:"not null;uniqueIndex:idx_UserRole_user_role"`
+	Role   string `gorm:"size:50;not null;uniqueIndex:idx_UserRole_user_role"`
+	// IDPemberi adalah admin yang memberikan role, kosong jika diberikan
+	// lewat command line
+	IDPemberi *uint
+}
+
+func (UserRole) TableName() string {
+	return "UserRole"
+}
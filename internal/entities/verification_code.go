@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// Channel verifikasi
+const (
+	VerificationEmail = "email"
+	VerificationPhone = "phone"
+)
+
+// VerificationCode adalah kode verifikasi yang dikirim ke email atau no
+// telp user. Target menyimpan alamat tujuan saat kode dikirim, sehingga kode
+// tidak berlaku jika alamatnya sudah diganti.
+type VerificationCode struct {
+	Model
+	IDUser    uint      `gorm:"not null;index:idx_VerificationCode_user_channel"`
+	Channel   string    `gorm:"size:10;not null;index:idx_VerificationCode_user_channel"`
+	Target    string    `gorm:"size:255;not null"`
+	CodeHash  string    `gorm:"size:64;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	// Attempts menghitung percobaan kode yang salah
+	Attempts int `gorm:"not null;default:0"`
+	UsedAt   *time.Time
+}
+
+func (VerificationCode) TableName() string {
+	return "VerificationCode"
+}
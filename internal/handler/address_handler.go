@@ -1,109 +1,79 @@
 package handler
 
 import (
-	"go-evermos/config"
-	"go-evermos/internal/entities"
-	"strconv"
+	"go-evermos/internal/dto"
+	"go-evermos/internal/repository"
+	"go-evermos/internal/service"
+	"go-evermos/pkg"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-// Create address
-func CreateAddress(c *fiber.Ctx) error {
-	userID := c.Locals("user_id").(uint)
+type AddressHandler struct {
+	addresses *service.AddressService
+}
 
-	var input struct {
-		JudulAlamat  string `json:"judul_alamat"`
-		NamaPenerima string `json:"nama_penerima"`
-		NoTelp       string `json:"no_telp"`
-		DetailAlamat string `json:"detail_alamat"`
-	}
+func NewAddressHandler(addresses *service.AddressService) *AddressHandler {
+	return &AddressHandler{addresses: addresses}
+}
+
+// Create address
+func (h *AddressHandler) CreateAddress(c *fiber.Ctx, p pkg.Principal) error {
+	var input service.AddressInput
 	if err := c.BodyParser(&input); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
 	}
 
-	address := entities.Address{
-		IDUser:       userID,
-		JudulAlamat:  input.JudulAlamat,
-		NamaPenerima: input.NamaPenerima,
-		NoTelp:       input.NoTelp,
-		DetailAlamat: input.DetailAlamat,
+	address, err := h.addresses.Create(p.UserID, input)
+	if err != nil {
+		return fail(c, err, "Gagal membuat alamat")
 	}
 
-	if err := config.DB.Create(&address).Error; err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal membuat alamat"})
-	}
-
-	return c.JSON(address)
+	return c.JSON(dto.NewAddress(address))
 }
 
 // Get all addresses for user
-func GetAddresses(c *fiber.Ctx) error {
-	userID := c.Locals("user_id").(uint)
-
-	var addresses []entities.Address
-	db := config.DB
-
-	// Pagination
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
-	offset := (page - 1) * limit
+func (h *AddressHandler) GetAddresses(c *fiber.Ctx, p pkg.Principal) error {
+	page := pageQuery(c)
 
 	// Filtering
-	if judul := c.Query("judul"); judul != "" {
-		db = db.Where("judul_alamat LIKE ?", "%"+judul+"%")
-	}
-	if penerima := c.Query("penerima"); penerima != "" {
-		db = db.Where("nama_penerima LIKE ?", "%"+penerima+"%")
-	}
-	if telp := c.Query("telp"); telp != "" {
-		db = db.Where("no_telp LIKE ?", "%"+telp+"%")
-	}
-
-	// Ambil data sesuai user
-	if err := db.Where("id_user = ?", userID).
-		Offset(offset).
-		Limit(limit).
-		Find(&addresses).Error; err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal ambil alamat"})
+	addresses, err := h.addresses.List(p.UserID, repository.AddressFilter{
+		Judul:    c.Query("judul"),
+		Penerima: c.Query("penerima"),
+		Telp:     c.Query("telp"),
+		Page:     page,
+	})
+	if err != nil {
+		return fail(c, err, "Gagal ambil alamat")
 	}
 
 	return c.JSON(fiber.Map{
-		"page":      page,
-		"limit":     limit,
-		"addresses": addresses,
+		"page":      page.Page,
+		"limit":     page.Limit,
+		"addresses": dto.NewAddresses(addresses),
 	})
 }
 
 // Update address
-func UpdateAddress(c *fiber.Ctx) error {
-    userID := c.Locals("user_id").(uint)
-    id := c.Params("id")
-
-    var address entities.Address
-    if err := config.DB.Where("id = ? AND id_user = ?", id, userID).First(&address).Error; err != nil {
-        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Alamat tidak ditemukan"})
-    }
-
-    if err := c.BodyParser(&address); err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
-    }
+func (h *AddressHandler) UpdateAddress(c *fiber.Ctx, p pkg.Principal) error {
+	var input service.AddressInput
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
 
-    if err := config.DB.Save(&address).Error; err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal update alamat"})
-    }
+	address, err := h.addresses.Update(p.UserID, paramID(c), input)
+	if err != nil {
+		return fail(c, err, "Gagal update alamat")
+	}
 
-    return c.JSON(fiber.Map{"message": "Alamat berhasil diupdate", "alamat": address})
+	return c.JSON(fiber.Map{"message": "Alamat berhasil diupdate", "alamat": dto.NewAddress(address)})
 }
 
 // Delete address
-func DeleteAddress(c *fiber.Ctx) error {
-    userID := c.Locals("user_id").(uint)
-    id := c.Params("id")
-
-    if err := config.DB.Where("id = ? AND id_user = ?", id, userID).Delete(&entities.Address{}).Error; err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal hapus alamat"})
-    }
+func (h *AddressHandler) DeleteAddress(c *fiber.Ctx, p pkg.Principal) error {
+	if err := h.addresses.Delete(p.UserID, paramID(c)); err != nil {
+		return fail(c, err, "Gagal hapus alamat")
+	}
 
-    return c.JSON(fiber.Map{"message": "Alamat berhasil dihapus"})
+	return c.JSON(fiber.Map{"message": "Alamat berhasil dihapus"})
 }
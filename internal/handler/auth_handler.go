@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"go-evermos/internal/dto"
+	"go-evermos/internal/repository"
+	"go-evermos/internal/service"
+	"go-evermos/pkg"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AuthHandler struct {
+	auth   *service.AuthService
+	resets *service.PasswordResetService
+}
+
+func NewAuthHandler(auth *service.AuthService, resets *service.PasswordResetService) *AuthHandler {
+	return &AuthHandler{auth: auth, resets: resets}
+}
+
+// Login menerima email atau no telp di field login. Field email tetap
+// diterima untuk client lama.
+func (h *AuthHandler) Login(c *fiber.Ctx) error {
+	var input struct {
+		Login    string `json:"login"`
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+	if input.Login == "" {
+		input.Login = input.Email
+	}
+
+	result, err := h.auth.Login(input.Login, input.Password, loginClient(c))
+	if err != nil {
+		return fail(c, err, "Gagal membuat token")
+	}
+
+	// User dengan 2FA harus mengirim kode ke /auth/2fa bersama challenge_token
+	if result.Tokens == nil {
+		return c.JSON(fiber.Map{
+			"two_factor_required": true,
+			"challenge_token":     result.Challenge,
+			"expires_in":          int(result.ChallengeExpiresIn.Seconds()),
+		})
+	}
+
+	return c.JSON(tokenResponse(result.Tokens))
+}
+
+// VerifyTwoFactor adalah langkah kedua login untuk user dengan 2FA
+func (h *AuthHandler) VerifyTwoFactor(c *fiber.Ctx) error {
+	var input struct {
+		ChallengeToken string `json:"challenge_token"`
+		Kode           string `json:"kode"`
+	}
+
+	if err := c.BodyParser(&input); err != nil || input.ChallengeToken == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "challenge_token wajib diisi"})
+	}
+
+	pair, err := h.auth.VerifyTwoFactor(input.ChallengeToken, input.Kode, loginClient(c))
+	if err != nil {
+		return fail(c, err, "Gagal membuat token")
+	}
+
+	return c.JSON(tokenResponse(pair))
+}
+
+func (h *AuthHandler) Refresh(c *fiber.Ctx) error {
+	var input struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	if err := c.BodyParser(&input); err != nil || input.RefreshToken == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "refresh_token wajib diisi"})
+	}
+
+	pair, err := h.auth.Refresh(input.RefreshToken)
+	if err != nil {
+		return fail(c, err, "Gagal memperbarui token")
+	}
+
+	return c.JSON(tokenResponse(pair))
+}
+
+// Logout mencabut token yang sedang dipakai dan sesi refresh token-nya
+func (h *AuthHandler) Logout(c *fiber.Ctx, p pkg.Principal) error {
+	if err := h.auth.Logout(p); err != nil {
+		return fail(c, err, "Gagal logout")
+	}
+
+	return c.JSON(fiber.Map{"message": "Logout sukses"})
+}
+
+// LogoutAll mencabut semua sesi user di semua perangkat
+func (h *AuthHandler) LogoutAll(c *fiber.Ctx, p pkg.Principal) error {
+	if err := h.auth.LogoutAll(p.UserID); err != nil {
+		return fail(c, err, "Gagal logout")
+	}
+
+	return c.JSON(fiber.Map{"message": "Semua sesi berhasil dicabut"})
+}
+
+// ChangePassword mengganti password. Sesi di perangkat lain dicabut,
+// perangkat ini mendapat token baru.
+func (h *AuthHandler) ChangePassword(c *fiber.Ctx, p pkg.Principal) error {
+	var input struct {
+		PasswordLama string `json:"password_lama"`
+		PasswordBaru string `json:"password_baru"`
+	}
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	pair, err := h.auth.ChangePassword(p.UserID, input.PasswordLama, input.PasswordBaru)
+	if err != nil {
+		return fail(c, err, "Gagal mengganti password")
+	}
+
+	return c.JSON(tokenResponse(pair))
+}
+
+// ForgotPassword mengirim link reset password. Respons selalu sama supaya
+// tidak bisa dipakai mengecek email mana yang terdaftar.
+func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
+	var input struct {
+		Email string `json:"email"`
+	}
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	if err := h.resets.Request(input.Email, c.IP()); err != nil {
+		return fail(c, err, "Gagal memproses permintaan reset password")
+	}
+
+	return c.JSON(fiber.Map{"message": "Jika email terdaftar, link reset password sudah dikirim"})
+}
+
+func (h *AuthHandler) ResetPassword(c *fiber.Ctx) error {
+	var input struct {
+		Token        string `json:"token"`
+		PasswordBaru string `json:"password_baru"`
+	}
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	if err := h.resets.Reset(input.Token, input.PasswordBaru); err != nil {
+		return fail(c, err, "Gagal reset password")
+	}
+
+	return c.JSON(fiber.Map{"message": "Password berhasil direset, silakan login"})
+}
+
+// JWKS menampilkan kunci publik supaya service lain bisa memverifikasi
+// access token tanpa memegang secret
+func (h *AuthHandler) JWKS(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(h.auth.JWKS())
+}
+
+// loginClient mengambil IP dan user agent client untuk pencatatan login
+func loginClient(c *fiber.Ctx) service.LoginClient {
+	return service.LoginClient{IP: c.IP(), UserAgent: c.Get(fiber.HeaderUserAgent)}
+}
+
+// tokenResponse tetap memakai key "token" untuk access token supaya client
+// lama tidak perlu diubah
+func tokenResponse(pair *service.TokenPair) fiber.Map {
+	return fiber.Map{
+		"token":         pair.AccessToken,
+		"refresh_token": pair.RefreshToken,
+		"expires_in":    int(pair.ExpiresIn.Seconds()),
+	}
+}
+
+// LoginAttempts menampilkan riwayat percobaan login (admin only), bisa
+// difilter per email, ip dan hasil
+func (h *AuthHandler) LoginAttempts(c *fiber.Ctx) error {
+	page := pageQuery(c)
+
+	attempts, total, err := h.auth.LoginAttempts(repository.LoginAttemptFilter{
+		Email:   c.Query("email"),
+		IP:      c.Query("ip"),
+		Outcome: c.Query("hasil"),
+		Page:    page,
+	})
+	if err != nil {
+		return fail(c, err, "Gagal ambil riwayat login")
+	}
+
+	totalPage := (total + int64(page.Limit) - 1) / int64(page.Limit)
+
+	return c.JSON(fiber.Map{
+		"page":           page.Page,
+		"limit":          page.Limit,
+		"total_data":     total,
+		"total_page":     totalPage,
+		"login_attempts": dto.NewLoginAttempts(attempts),
+	})
+}
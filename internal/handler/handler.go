@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	"go-evermos/internal/repository"
+	"go-evermos/internal/service"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Handlers mengumpulkan semua handler HTTP untuk didaftarkan di router
+type Handlers struct {
+	Auth         *AuthHandler
+	User         *UserHandler
+	Store        *StoreHandler
+	Address      *AddressHandler
+	Category     *CategoryHandler
+	Product      *ProductHandler
+	Import       *ImportHandler
+	Transaction  *TransactionHandler
+	Notification *NotificationHandler
+	Health       *HealthHandler
+	Verification *VerificationHandler
+	TwoFactor    *TwoFactorHandler
+	Role         *RoleHandler
+}
+
+// Services adalah dependency yang dibutuhkan handler
+type Services struct {
+	Auth           *service.AuthService
+	PasswordResets *service.PasswordResetService
+	Users          *service.UserService
+	Stores         *service.StoreService
+	Addresses      *service.AddressService
+	Categories     *service.CategoryService
+	Products       *service.ProductService
+	Imports        *service.ImportService
+	Transactions   *service.TransactionService
+	Notifications  *service.NotificationService
+	Health         *service.HealthService
+	Verifications  *service.VerificationService
+	TwoFactor      *service.TwoFactorService
+	Roles          *service.RoleService
+}
+
+func New(s Services) Handlers {
+	return Handlers{
+		Auth:         NewAuthHandler(s.Auth, s.PasswordResets),
+		User:         NewUserHandler(s.Users),
+		Store:        NewStoreHandler(s.Stores),
+		Address:      NewAddressHandler(s.Addresses),
+		Category:     NewCategoryHandler(s.Categories),
+		Product:      NewProductHandler(s.Products),
+		Import:       NewImportHandler(s.Imports),
+		Transaction:  NewTransactionHandler(s.Transactions),
+		Notification: NewNotificationHandler(s.Notifications),
+		Health:       NewHealthHandler(s.Health),
+		Verification: NewVerificationHandler(s.Verifications),
+		TwoFactor:    NewTwoFactorHandler(s.TwoFactor),
+		Role:         NewRoleHandler(s.Roles),
+	}
+}
+
+// fail menulis error dari service sebagai JSON. Error bisnis memakai status
+// dan pesannya sendiri, error lain menjadi 500 dengan pesan fallback.
+func fail(c *fiber.Ctx, err error, fallback string) error {
+	var e *service.Error
+	if errors.As(err, &e) {
+		if e.RetryAfter > 0 {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(e.RetryAfter/time.Second)))
+		}
+		return c.Status(e.Status).JSON(fiber.Map{"error": e.Message})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fallback})
+}
+
+// paramID membaca parameter :id sebagai uint (0 jika bukan angka)
+func paramID(c *fiber.Ctx) uint {
+	id, _ := strconv.ParseUint(c.Params("id"), 10, 64)
+	return uint(id)
+}
+
+// pageQuery membaca query page & limit
+func pageQuery(c *fiber.Ctx) repository.Page {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	return repository.Page{Page: page, Limit: limit}.Normalize()
+}
@@ -0,0 +1,214 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go-evermos/internal/entities"
+	"go-evermos/internal/handler"
+	"go-evermos/internal/mail"
+	"go-evermos/internal/repository"
+	"go-evermos/internal/repository/memory"
+	"go-evermos/internal/router"
+	"go-evermos/internal/service"
+	"go-evermos/internal/sms"
+	"go-evermos/pkg"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// leakedFields adalah key yang tidak boleh muncul di response mana pun,
+// baik nama kolom maupun nama field Go jika entity terkirim tanpa DTO
+var leakedFields = []string{"kata_sandi", "KataSandi", "totp_secret", "TOTPSecret", "deleted_at", "DeletedAt"}
+
+// testApp menyusun app seperti main, tetapi tanpa pkg.BlockFields supaya
+// test memeriksa DTO handler, bukan jaring pengamannya
+func testApp(t *testing.T, repos *repository.Repositories) (*fiber.App, *service.AuthService) {
+	t.Helper()
+
+	tokens := pkg.NewTokenManager("secret-untuk-test", pkg.TokenOptions{
+		TTL:      15 * time.Minute,
+		Issuer:   "go-evermos",
+		Audience: "go-evermos-api",
+		Leeway:   30 * time.Second,
+	})
+	mailer := &mail.FileMailer{Dir: t.TempDir()}
+	verifications := service.NewVerificationService(repos, mailer, sms.LogSender{}, time.Hour, time.Minute)
+	auth := service.NewAuthService(repos, tokens, time.Hour, service.LoginLimits{})
+	services := handler.Services{
+		Auth:           auth,
+		PasswordResets: service.NewPasswordResetService(repos, mailer, time.Hour, ""),
+		Users:          service.NewUserService(repos, verifications),
+		Stores:         service.NewStoreService(repos),
+		Addresses:      service.NewAddressService(repos),
+		Categories:     service.NewCategoryService(repos),
+		Products:       service.NewProductService(repos),
+		Imports:        service.NewImportService(repos),
+		Transactions:   service.NewTransactionService(repos, time.Hour, verifications),
+		Notifications:  service.NewNotificationService(repos),
+		Health:         service.NewHealthService(nil, nil, t.TempDir()),
+		Verifications:  verifications,
+		TwoFactor:      service.NewTwoFactorService(repos, auth, "go-evermos", false),
+		Roles:          service.NewRoleService(repos),
+	}
+	t.Cleanup(verifications.Wait)
+
+	app := fiber.New(fiber.Config{ErrorHandler: pkg.ErrorHandler})
+	app.Use(pkg.RequestID())
+	deps := router.Deps{
+		Tokens:      tokens,
+		Revocations: services.Auth,
+		Stores:      services.Stores,
+		Policy:      service.AccountPolicies{services.Verifications, services.TwoFactor},
+		Roles:       services.Roles,
+	}
+	if err := router.Register(app, router.Routes(handler.New(services)), deps); err != nil {
+		t.Fatal(err)
+	}
+	return app, auth
+}
+
+// seedAccount membuat user terverifikasi dengan toko, kata sandi dan secret
+// TOTP terisi, lalu login dan mengembalikan access token-nya
+func seedAccount(t *testing.T, repos *repository.Repositories, auth *service.AuthService, nama, notelp string) (*entities.User, *entities.Store, string) {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("rahasia123"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	secret := "JBSWY3DPEHPK3PXP"
+	user := &entities.User{
+		Nama:            nama,
+		KataSandi:       string(hash),
+		Notelp:          notelp,
+		TanggalLahir:    time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		Email:           nama + "@x.com",
+		EmailVerifiedAt: &now,
+		PhoneVerifiedAt: &now,
+		TOTPSecret:      &secret,
+	}
+	if err := repos.Users.Create(user); err != nil {
+		t.Fatal(err)
+	}
+	store := &entities.Store{IDUser: user.ID}
+	if err := repos.Stores.Create(store); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := auth.Login(user.Email, "rahasia123", service.LoginClient{IP: "127.0.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user, store, res.Tokens.AccessToken
+}
+
+// call mengirim request lewat app.Test dan memastikan status serta tidak
+// ada field sensitif di body
+func call(t *testing.T, app *fiber.App, method, path, token string, body any, status int) []byte {
+	t.Helper()
+
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r = bytes.NewReader(b)
+	}
+	req := httptest.NewRequest(method, path, r)
+	if body != nil {
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	}
+	if token != "" {
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+	}
+
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	got, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != status {
+		t.Fatalf("status %d, seharusnya %d: %s", resp.StatusCode, status, got)
+	}
+	for _, f := range leakedFields {
+		if bytes.Contains(got, []byte(`"`+f+`"`)) {
+			t.Errorf("response memuat field %q: %s", f, got)
+		}
+	}
+	return got
+}
+
+func TestResponsesHideSensitiveFields(t *testing.T) {
+	repos := memory.New()
+	app, auth := testApp(t, repos)
+
+	_, store, sellerToken := seedAccount(t, repos, auth, "penjual", "+6281100000001")
+	buyer, _, buyerToken := seedAccount(t, repos, auth, "pembeli", "+6281100000002")
+	admin, _, _ := seedAccount(t, repos, auth, "admin", "+6281100000003")
+	if err := repos.UserRoles.Create(&entities.UserRole{IDUser: admin.ID, Role: pkg.RoleAdmin}); err != nil {
+		t.Fatal(err)
+	}
+	// Login ulang supaya token admin memuat role barunya
+	res, err := auth.Login(admin.Email, "rahasia123", service.LoginClient{IP: "127.0.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	adminToken := res.Tokens.AccessToken
+
+	produk := &entities.Product{
+		NamaProduk:    "Produk",
+		Slug:          "produk",
+		HargaReseller: "700",
+		HargaKonsumen: "1000",
+		IDToko:        store.ID,
+		Stok:          10,
+		Status:        entities.ProductStatusActive,
+	}
+	if err := repos.Products.Create(produk); err != nil {
+		t.Fatal(err)
+	}
+	alamat := &entities.Address{IDUser: buyer.ID, JudulAlamat: "Rumah", NamaPenerima: "Pembeli", NoTelp: "+6281100000002", DetailAlamat: "Jl. Contoh 1"}
+	if err := repos.Addresses.Create(alamat); err != nil {
+		t.Fatal(err)
+	}
+	call(t, app, fiber.MethodPost, "/transactions", buyerToken, fiber.Map{
+		"id_alamat":    alamat.ID,
+		"method_bayar": "cod",
+		"items":        []fiber.Map{{"id_produk": produk.ID, "qty": 2}},
+	}, http.StatusOK)
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		body   any
+	}{
+		{"profil", fiber.MethodGet, "/user/profile", buyerToken, nil},
+		{"update profil", fiber.MethodPut, "/user/profile", buyerToken, fiber.Map{"nama": "Pembeli Baru", "no_telp": "+6281100000002"}},
+		{"daftar produk", fiber.MethodGet, "/products", "", nil},
+		{"detail produk", fiber.MethodGet, fmt.Sprintf("/product/%d", produk.ID), "", nil},
+		{"daftar produk penjual", fiber.MethodGet, "/products", sellerToken, nil},
+		{"transaksi pembeli", fiber.MethodGet, "/transactions", buyerToken, nil},
+		{"riwayat login", fiber.MethodGet, "/admin/login-attempts", adminToken, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			call(t, app, tc.method, tc.path, tc.token, tc.body, http.StatusOK)
+		})
+	}
+}
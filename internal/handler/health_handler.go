@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"context"
+	"go-evermos/internal/service"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// readyTimeout membatasi lama pengecekan readiness supaya probe orchestrator
+// tidak menggantung saat database lambat
+const readyTimeout = 3 * time.Second
+
+type HealthHandler struct {
+	health *service.HealthService
+}
+
+func NewHealthHandler(health *service.HealthService) *HealthHandler {
+	return &HealthHandler{health: health}
+}
+
+// Healthz (liveness) hanya memastikan proses masih melayani request
+func (h *HealthHandler) Healthz(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"status": "ok"})
+}
+
+// Readyz (readiness) bernilai 503 jika ada komponen yang belum siap
+func (h *HealthHandler) Readyz(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), readyTimeout)
+	defer cancel()
+
+	r := h.health.Ready(ctx)
+	status, code := "ok", fiber.StatusOK
+	if !r.Ready {
+		status, code = "unavailable", fiber.StatusServiceUnavailable
+	}
+
+	return c.Status(code).JSON(fiber.Map{
+		"status": status,
+		"checks": r.Checks,
+	})
+}
+
+// Version menampilkan commit, waktu build dan versi skema database
+func (h *HealthHandler) Version(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), readyTimeout)
+	defer cancel()
+
+	return c.JSON(h.health.Version(ctx))
+}
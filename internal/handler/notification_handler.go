@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"go-evermos/internal/dto"
+	"go-evermos/internal/repository"
+	"go-evermos/internal/service"
+	"go-evermos/pkg"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type NotificationHandler struct {
+	notifications *service.NotificationService
+}
+
+func NewNotificationHandler(notifications *service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notifications: notifications}
+}
+
+// GetNotifications mengambil notifikasi milik user login
+func (h *NotificationHandler) GetNotifications(c *fiber.Ctx, p pkg.Principal) error {
+	page := pageQuery(c)
+
+	// Filtering
+	notifs, err := h.notifications.List(p.UserID, repository.NotificationFilter{
+		Unread: c.Query("unread") == "true",
+		Tipe:   c.Query("tipe"),
+		Page:   page,
+	})
+	if err != nil {
+		return fail(c, err, "Gagal ambil notifikasi")
+	}
+
+	return c.JSON(fiber.Map{
+		"page":          page.Page,
+		"limit":         page.Limit,
+		"notifications": dto.NewNotifications(notifs),
+	})
+}
+
+// ReadNotification menandai notifikasi sudah dibaca
+func (h *NotificationHandler) ReadNotification(c *fiber.Ctx, p pkg.Principal) error {
+	if err := h.notifications.MarkRead(p.UserID, paramID(c)); err != nil {
+		return fail(c, err, "Gagal update notifikasi")
+	}
+
+	return c.JSON(fiber.Map{"message": "Notifikasi ditandai sudah dibaca"})
+}
@@ -2,39 +2,46 @@ package handler
 
 import (
 	"fmt"
-	"go-evermos/config"
-	"go-evermos/internal/entities"
+	"go-evermos/internal/dto"
+	"go-evermos/internal/repository"
+	"go-evermos/internal/service"
+	"go-evermos/pkg"
 	"mime/multipart"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
-	"strconv"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gosimple/slug"
 )
 
-func CreateProduct(c *fiber.Ctx) error {
-	userID := c.Locals("user_id").(uint)
+type ProductHandler struct {
+	products *service.ProductService
+}
 
-	// ambil toko milik user
-	var store entities.Store
-	if err := config.DB.Where("id_user = ?", userID).First(&store).Error; err != nil {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Toko tidak ditemukan"})
-	}
+func NewProductHandler(products *service.ProductService) *ProductHandler {
+	return &ProductHandler{products: products}
+}
 
+func (h *ProductHandler) CreateProduct(c *fiber.Ctx, p pkg.Principal) error {
 	// ambil form data
-	namaProduk := c.FormValue("nama_produk")
-	hargaReseller := c.FormValue("harga_reseller")
-	hargaKonsumen := c.FormValue("harga_konsumen")
-	deskripsi := c.FormValue("deskripsi")
-	idCategory := c.FormValue("id_category")
-	stok := c.FormValue("stok")
-
-	if namaProduk == "" || hargaReseller == "" || hargaKonsumen == "" || idCategory == "" || stok == "" {
+	input := service.ProductInput{
+		NamaProduk:    c.FormValue("nama_produk"),
+		HargaReseller: c.FormValue("harga_reseller"),
+		HargaKonsumen: c.FormValue("harga_konsumen"),
+		Deskripsi:     c.FormValue("deskripsi"),
+		IDCategory:    parseUint(c.FormValue("id_category")),
+		Stok:          parseInt(c.FormValue("stok")),
+		StokMinimum:   parseInt(c.FormValue("stok_minimum")),
+		Status:        c.FormValue("status"),
+	}
+
+	if input.NamaProduk == "" || input.HargaReseller == "" || input.HargaKonsumen == "" || c.FormValue("id_category") == "" || c.FormValue("stok") == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Field wajib diisi"})
 	}
+	if input.Status != "" && !service.IsOwnerProductStatus(input.Status) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Status harus draft, active atau archived"})
+	}
 
 	// upload file
 	file, err := c.FormFile("foto")
@@ -46,39 +53,20 @@ func CreateProduct(c *fiber.Ctx) error {
 		}
 	}
 
-	// simpan produk
-	produk := entities.Product{
-		NamaProduk:    namaProduk,
-		Slug:          slug.Make(namaProduk),
-		HargaReseller: hargaReseller,
-		HargaKonsumen: hargaKonsumen,
-		Deskripsi:     &deskripsi,
-		IDToko:        store.ID,
-		IDCategory:    parseUint(idCategory),
-		Stok:          parseInt(stok),
-	}
-	if err := config.DB.Create(&produk).Error; err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal simpan produk"})
-	}
-
-	// simpan foto
-	if fotoPath != "" {
-		foto := entities.ProductPicture{
-			IDProduk: produk.ID,
-			Url:      fotoPath,
-		}
-		config.DB.Create(&foto)
+	produk, err := h.products.Create(p.UserID, input, fotoPath)
+	if err != nil {
+		return fail(c, err, "Gagal simpan produk")
 	}
 
 	return c.JSON(fiber.Map{
 		"message": "Produk berhasil dibuat",
-		"produk":  produk,
+		"produk":  dto.NewProduct(produk),
 	})
 }
 
 // fungsi untuk simpan file
 func saveFile(c *fiber.Ctx, file *multipart.FileHeader) (string, error) {
-	dir := "./uploads"
+	dir := service.UploadDir
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
 		os.Mkdir(dir, os.ModePerm)
 	}
@@ -104,131 +92,65 @@ func parseInt(s string) int {
 	return i
 }
 
-func GetAllProducts(c *fiber.Ctx) error {
-	var products []entities.Product
-	db := config.DB.Model(&entities.Product{})
+func (h *ProductHandler) GetAllProducts(c *fiber.Ctx) error {
+	page := pageQuery(c)
 
 	// Filtering
-	if nama := c.Query("nama"); nama != "" {
-		db = db.Where("nama_produk LIKE ?", "%"+nama+"%")
-	}
-	if category := c.Query("category"); category != "" {
-		db = db.Where("id_category = ?", category)
-	}
-	if minPrice := c.Query("min_price"); minPrice != "" {
-		db = db.Where("harga_konsumen >= ?", minPrice)
-	}
-	if maxPrice := c.Query("max_price"); maxPrice != "" {
-		db = db.Where("harga_konsumen <= ?", maxPrice)
-	}
-	if toko := c.Query("toko"); toko != "" {
-		db = db.Where("id_toko = ?", toko)
-	}
-
-	// Pagination
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 {
-		limit = 10
-	}
-	offset := (page - 1) * limit
-
-	// Hitung total data (setelah filter)
-	var total int64
-	if err := db.Count(&total).Error; err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal menghitung data"})
-	}
-
-	// Ambil data
-	if err := db.Preload("ProductPicture").Preload("Category").Preload("Store").
-		Offset(offset).Limit(limit).Find(&products).Error; err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal ambil produk"})
+	products, total, err := h.products.ListPublic(repository.ProductFilter{
+		Nama:     c.Query("nama"),
+		Category: c.Query("category"),
+		MinPrice: c.Query("min_price"),
+		MaxPrice: c.Query("max_price"),
+		Toko:     c.Query("toko"),
+		Page:     page,
+	})
+	if err != nil {
+		return fail(c, err, "Gagal ambil produk")
 	}
 
-	totalPage := (total + int64(limit) - 1) / int64(limit)
+	totalPage := (total + int64(page.Limit) - 1) / int64(page.Limit)
 
 	return c.JSON(fiber.Map{
-		"page":        page,
-		"limit":       limit,
-		"total_data":  total,
-		"total_page":  totalPage,
-		"products":    products,
+		"page":       page.Page,
+		"limit":      page.Limit,
+		"total_data": total,
+		"total_page": totalPage,
+		"products":   dto.NewProducts(products),
 	})
 }
 
-
-func GetProductByID(c *fiber.Ctx) error {
-	id := c.Params("id")
-
-	var produk entities.Product
-	if err := config.DB.Preload("ProductPicture").
-		Preload("Category").
-		Preload("Store").
-		First(&produk, id).Error; err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Produk tidak ditemukan"})
+func (h *ProductHandler) GetProductByID(c *fiber.Ctx) error {
+	var viewer *pkg.Principal
+	if p, ok := pkg.PrincipalFrom(c); ok {
+		viewer = &p
 	}
 
-	return c.JSON(produk)
-}
-
-func UpdateProduct(c *fiber.Ctx) error {
-	userID := c.Locals("user_id").(uint)
-	id := c.Params("id")
-
-	var produk entities.Product
-	if err := config.DB.Joins("JOIN Toko ON Toko.id = Produk.id_toko").
-		Where("Produk.id = ? AND Toko.id_user = ?", id, userID).
-		First(&produk).Error; err != nil {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Produk tidak ditemukan atau bukan milik Anda"})
+	produk, err := h.products.Get(paramID(c), viewer)
+	if err != nil {
+		return fail(c, err, "Gagal ambil produk")
 	}
 
-	var input struct {
-		NamaProduk    string `json:"nama_produk"`
-		HargaReseller string `json:"harga_reseller"`
-		HargaKonsumen string `json:"harga_konsumen"`
-		Stok          int    `json:"stok"`
-		Deskripsi     string `json:"deskripsi"`
-		IDCategory    uint   `json:"id_category"`
-	}
+	return c.JSON(dto.NewProduct(produk))
+}
 
+func (h *ProductHandler) UpdateProduct(c *fiber.Ctx, p pkg.Principal) error {
+	var input service.ProductInput
 	if err := c.BodyParser(&input); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
 	}
 
-	produk.NamaProduk = input.NamaProduk
-	produk.Slug = slug.Make(input.NamaProduk)
-	produk.HargaReseller = input.HargaReseller
-	produk.HargaKonsumen = input.HargaKonsumen
-	produk.Stok = input.Stok
-	produk.Deskripsi = &input.Deskripsi
-	produk.IDCategory = input.IDCategory
-
-	if err := config.DB.Save(&produk).Error; err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal update produk"})
+	produk, err := h.products.Update(p.UserID, paramID(c), input)
+	if err != nil {
+		return fail(c, err, "Gagal update produk")
 	}
 
-	return c.JSON(fiber.Map{"message": "Produk berhasil diupdate", "produk": produk})
+	return c.JSON(fiber.Map{"message": "Produk berhasil diupdate", "produk": dto.NewProduct(produk)})
 }
 
-
-func DeleteProduct(c *fiber.Ctx) error {
-	userID := c.Locals("user_id").(uint)
-	id := c.Params("id")
-
-	var produk entities.Product
-	if err := config.DB.Joins("JOIN Toko ON Toko.id = Produk.id_toko").
-		Where("Produk.id = ? AND Toko.id_user = ?", id, userID).
-		First(&produk).Error; err != nil {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Produk tidak ditemukan atau bukan milik Anda"})
-	}
-
-	if err := config.DB.Delete(&produk).Error; err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal hapus produk"})
+func (h *ProductHandler) DeleteProduct(c *fiber.Ctx, p pkg.Principal) error {
+	if err := h.products.Delete(p.UserID, paramID(c)); err != nil {
+		return fail(c, err, "Gagal hapus produk")
 	}
 
 	return c.JSON(fiber.Map{"message": "Produk berhasil dihapus"})
 }
-
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"fmt"
+	"go-evermos/internal/dto"
+	"go-evermos/internal/service"
+	"go-evermos/pkg"
+	"io"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ImportHandler struct {
+	imports *service.ImportService
+}
+
+func NewImportHandler(imports *service.ImportService) *ImportHandler {
+	return &ImportHandler{imports: imports}
+}
+
+// ImportProducts menerima file CSV/XLSX lalu memprosesnya di background
+func (h *ImportHandler) ImportProducts(c *fiber.Ctx, p pkg.Principal) error {
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "File import wajib diisi"})
+	}
+
+	if service.SheetFormat(file.Filename) == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Format file harus csv atau xlsx"})
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Gagal membaca file"})
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Gagal membaca file"})
+	}
+
+	dryRun := c.Query("dry_run") == "true" || c.FormValue("dry_run") == "true"
+
+	job, err := h.imports.Start(p.UserID, file.Filename, data, dryRun)
+	if err != nil {
+		return fail(c, err, "Gagal membuat job import")
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"message": "Import sedang diproses",
+		"job":     dto.NewImportJob(job),
+	})
+}
+
+// GetImportJob menampilkan status dan error per baris dari job import
+func (h *ImportHandler) GetImportJob(c *fiber.Ctx, p pkg.Principal) error {
+	job, err := h.imports.Get(p.UserID, paramID(c))
+	if err != nil {
+		return fail(c, err, "Gagal ambil job import")
+	}
+
+	return c.JSON(dto.NewImportJob(job))
+}
+
+// ExportProducts mengunduh katalog toko dalam format yang sama dengan import
+func (h *ImportHandler) ExportProducts(c *fiber.Ctx, p pkg.Principal) error {
+	format := strings.ToLower(c.Query("format", "csv"))
+
+	data, storeID, err := h.imports.Export(p.UserID, format)
+	if err != nil {
+		return fail(c, err, "Gagal membuat file export")
+	}
+
+	if format == "xlsx" {
+		c.Set(fiber.HeaderContentType, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	} else {
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	}
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="produk-toko-%d.%s"`, storeID, format))
+
+	return c.Send(data)
+}
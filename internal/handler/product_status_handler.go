@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"go-evermos/internal/dto"
+	"go-evermos/internal/repository"
+	"go-evermos/pkg"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetMyProducts mengambil semua produk toko user login, termasuk draft/archived/banned
+func (h *ProductHandler) GetMyProducts(c *fiber.Ctx, p pkg.Principal) error {
+	page := pageQuery(c)
+
+	// Filtering
+	products, total, err := h.products.ListMine(p.UserID, repository.ProductFilter{
+		Status: c.Query("status"),
+		Nama:   c.Query("nama"),
+		Page:   page,
+	})
+	if err != nil {
+		return fail(c, err, "Gagal ambil produk")
+	}
+
+	return c.JSON(fiber.Map{
+		"page":       page.Page,
+		"limit":      page.Limit,
+		"total_data": total,
+		"total_page": (total + int64(page.Limit) - 1) / int64(page.Limit),
+		"products":   dto.NewProducts(products),
+	})
+}
+
+// UpdateProductStatus mengubah status publikasi produk oleh pemilik toko
+func (h *ProductHandler) UpdateProductStatus(c *fiber.Ctx, p pkg.Principal) error {
+	var input struct {
+		Status string `json:"status"`
+	}
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	produk, err := h.products.UpdateStatus(p.UserID, paramID(c), input.Status)
+	if err != nil {
+		return fail(c, err, "Gagal update status produk")
+	}
+
+	return c.JSON(fiber.Map{"message": "Status produk berhasil diupdate", "produk": dto.NewProduct(produk)})
+}
+
+// BanProduct memblokir produk (admin only) beserta alasannya
+func (h *ProductHandler) BanProduct(c *fiber.Ctx) error {
+	var input struct {
+		Alasan string `json:"alasan"`
+	}
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	produk, err := h.products.Ban(paramID(c), input.Alasan)
+	if err != nil {
+		return fail(c, err, "Gagal blokir produk")
+	}
+
+	return c.JSON(fiber.Map{"message": "Produk berhasil diblokir", "produk": dto.NewProduct(produk)})
+}
+
+// UnbanProduct membuka blokir produk. Produk kembali ke draft supaya pemilik
+// toko memeriksa ulang sebelum dipublikasikan.
+func (h *ProductHandler) UnbanProduct(c *fiber.Ctx) error {
+	produk, err := h.products.Unban(paramID(c))
+	if err != nil {
+		return fail(c, err, "Gagal buka blokir produk")
+	}
+
+	return c.JSON(fiber.Map{"message": "Blokir produk dibuka", "produk": dto.NewProduct(produk)})
+}
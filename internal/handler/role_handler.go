@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"go-evermos/internal/service"
+	"go-evermos/pkg"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RoleHandler struct {
+	roles *service.RoleService
+}
+
+func NewRoleHandler(roles *service.RoleService) *RoleHandler {
+	return &RoleHandler{roles: roles}
+}
+
+// Catalog menampilkan semua role beserta izinnya
+func (h *RoleHandler) Catalog(c *fiber.Ctx) error {
+	roles := h.roles.Catalog()
+
+	items := make([]fiber.Map, 0, len(roles))
+	for _, r := range roles {
+		items = append(items, fiber.Map{
+			"role":        r.Role,
+			"izin":        r.Permissions,
+			"bisa_diberi": r.Grantable,
+		})
+	}
+	return c.JSON(fiber.Map{"roles": items})
+}
+
+func (h *RoleHandler) UserRoles(c *fiber.Ctx) error {
+	id := paramID(c)
+	roles, err := h.roles.Roles(id)
+	if err != nil {
+		return fail(c, err, "Gagal ambil role user")
+	}
+
+	return c.JSON(fiber.Map{"id_user": id, "roles": roles})
+}
+
+func (h *RoleHandler) Grant(c *fiber.Ctx, p pkg.Principal) error {
+	var input struct {
+		Role string `json:"role"`
+	}
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	id := paramID(c)
+	roles, err := h.roles.Grant(p.UserID, id, input.Role)
+	if err != nil {
+		return fail(c, err, "Gagal memberikan role")
+	}
+
+	return c.JSON(fiber.Map{"message": "Role berhasil diberikan", "id_user": id, "roles": roles})
+}
+
+func (h *RoleHandler) Revoke(c *fiber.Ctx) error {
+	id := paramID(c)
+	roles, err := h.roles.Revoke(id, c.Params("role"))
+	if err != nil {
+		return fail(c, err, "Gagal mencabut role")
+	}
+
+	return c.JSON(fiber.Map{"message": "Role berhasil dicabut", "id_user": id, "roles": roles})
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"go-evermos/internal/dto"
+	"go-evermos/internal/repository"
+	"go-evermos/internal/service"
+	"go-evermos/pkg"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetStockHistory menampilkan riwayat mutasi stok sebuah produk (khusus pemilik toko)
+func (h *ProductHandler) GetStockHistory(c *fiber.Ctx, p pkg.Principal) error {
+	page := pageQuery(c)
+
+	// Filtering
+	history, err := h.products.StockHistory(p.UserID, paramID(c), repository.StockMovementFilter{
+		Tipe: c.Query("tipe"),
+		Page: page,
+	})
+	if err != nil {
+		return fail(c, err, "Gagal ambil riwayat stok")
+	}
+
+	return c.JSON(fiber.Map{
+		"page":          page.Page,
+		"limit":         page.Limit,
+		"stok_sekarang": history.Produk.Stok,
+		"stok_ledger":   history.StokLedger,
+		"sesuai":        history.StokLedger == history.Produk.Stok,
+		"riwayat":       dto.NewStockMovements(history.Riwayat),
+	})
+}
+
+// AdjustStock mengubah stok secara manual (koreksi stok atau retur barang)
+func (h *ProductHandler) AdjustStock(c *fiber.Ctx, p pkg.Principal) error {
+	var input service.AdjustStockInput
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	produk, err := h.products.AdjustStock(p.UserID, paramID(c), input)
+	if err != nil {
+		return fail(c, err, "Gagal update stok")
+	}
+
+	return c.JSON(fiber.Map{"message": "Stok berhasil diupdate", "produk": dto.NewProduct(produk)})
+}
+
+// ReconcileStock membandingkan stok setiap produk toko dengan jumlah mutasi di ledger
+func (h *ProductHandler) ReconcileStock(c *fiber.Ctx, p pkg.Principal) error {
+	total, mismatches, err := h.products.ReconcileStock(p.UserID)
+	if err != nil {
+		return fail(c, err, "Gagal rekonsiliasi stok")
+	}
+
+	return c.JSON(fiber.Map{
+		"total_produk": total,
+		"sesuai":       len(mismatches) == 0,
+		"selisih":      mismatches,
+	})
+}
+
+// SubscribeRestock mendaftarkan user untuk diberi tahu saat produk tersedia kembali
+func (h *ProductHandler) SubscribeRestock(c *fiber.Ctx, p pkg.Principal) error {
+	if err := h.products.SubscribeRestock(p.UserID, paramID(c)); err != nil {
+		return fail(c, err, "Gagal berlangganan notifikasi")
+	}
+
+	return c.JSON(fiber.Map{"message": "Anda akan diberi tahu saat produk tersedia kembali"})
+}
+
+// UnsubscribeRestock membatalkan langganan notifikasi stok
+func (h *ProductHandler) UnsubscribeRestock(c *fiber.Ctx, p pkg.Principal) error {
+	if err := h.products.UnsubscribeRestock(p.UserID, paramID(c)); err != nil {
+		return fail(c, err, "Gagal batal berlangganan")
+	}
+
+	return c.JSON(fiber.Map{"message": "Langganan notifikasi dibatalkan"})
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"go-evermos/internal/service"
+	"go-evermos/pkg"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TwoFactorHandler struct {
+	twoFactor *service.TwoFactorService
+}
+
+func NewTwoFactorHandler(twoFactor *service.TwoFactorService) *TwoFactorHandler {
+	return &TwoFactorHandler{twoFactor: twoFactor}
+}
+
+func (h *TwoFactorHandler) Status(c *fiber.Ctx, p pkg.Principal) error {
+	status, err := h.twoFactor.Status(p.UserID)
+	if err != nil {
+		return fail(c, err, "Gagal ambil status 2FA")
+	}
+
+	return c.JSON(fiber.Map{
+		"aktif":              status.Enabled,
+		"wajib":              status.Required,
+		"sisa_kode_cadangan": status.RecoveryCodesLeft,
+	})
+}
+
+// Setup membuat secret TOTP; otpauth_url ditampilkan sebagai QR code
+func (h *TwoFactorHandler) Setup(c *fiber.Ctx, p pkg.Principal) error {
+	setup, err := h.twoFactor.Setup(p.UserID)
+	if err != nil {
+		return fail(c, err, "Gagal menyiapkan 2FA")
+	}
+
+	return c.JSON(fiber.Map{"secret": setup.Secret, "otpauth_url": setup.URI})
+}
+
+// Enable mengaktifkan 2FA dan mengembalikan token baru serta kode cadangan
+// (hanya ditampilkan sekali)
+func (h *TwoFactorHandler) Enable(c *fiber.Ctx, p pkg.Principal) error {
+	var input struct {
+		Kode string `json:"kode"`
+	}
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	pair, codes, err := h.twoFactor.Enable(p.UserID, input.Kode)
+	if err != nil {
+		return fail(c, err, "Gagal mengaktifkan 2FA")
+	}
+
+	resp := tokenResponse(pair)
+	resp["kode_cadangan"] = codes
+	return c.JSON(resp)
+}
+
+func (h *TwoFactorHandler) Disable(c *fiber.Ctx, p pkg.Principal) error {
+	var input struct {
+		Password string `json:"password"`
+		Kode     string `json:"kode"`
+	}
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	if err := h.twoFactor.Disable(p.UserID, input.Password, input.Kode); err != nil {
+		return fail(c, err, "Gagal menonaktifkan 2FA")
+	}
+
+	return c.JSON(fiber.Map{"message": "2FA dinonaktifkan"})
+}
+
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *fiber.Ctx, p pkg.Principal) error {
+	var input struct {
+		Kode string `json:"kode"`
+	}
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	codes, err := h.twoFactor.RegenerateRecoveryCodes(p.UserID, input.Kode)
+	if err != nil {
+		return fail(c, err, "Gagal membuat kode cadangan")
+	}
+
+	return c.JSON(fiber.Map{"kode_cadangan": codes})
+}
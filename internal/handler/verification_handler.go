@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"go-evermos/internal/service"
+	"go-evermos/pkg"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type VerificationHandler struct {
+	verifications *service.VerificationService
+}
+
+func NewVerificationHandler(verifications *service.VerificationService) *VerificationHandler {
+	return &VerificationHandler{verifications: verifications}
+}
+
+// SendCode mengirim kode verifikasi ke email atau no telp (:channel)
+func (h *VerificationHandler) SendCode(c *fiber.Ctx, p pkg.Principal) error {
+	if err := h.verifications.Send(p.UserID, c.Params("channel")); err != nil {
+		return fail(c, err, "Gagal mengirim kode verifikasi")
+	}
+
+	return c.JSON(fiber.Map{"message": "Kode verifikasi sudah dikirim"})
+}
+
+func (h *VerificationHandler) Verify(c *fiber.Ctx, p pkg.Principal) error {
+	var input struct {
+		Kode string `json:"kode"`
+	}
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	if err := h.verifications.Verify(p.UserID, c.Params("channel"), input.Kode); err != nil {
+		return fail(c, err, "Gagal verifikasi")
+	}
+
+	return c.JSON(fiber.Map{"message": "Verifikasi berhasil"})
+}
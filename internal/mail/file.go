@@ -0,0 +1,46 @@
+package mail
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileMailer tidak mengirim email, tetapi menyimpan setiap email sebagai file
+// .eml di Dir supaya bisa dibuka saat development atau dibaca oleh test.
+// Jika Dir kosong, isi email ditulis ke log.
+type FileMailer struct {
+	Dir  string
+	From string
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	now := time.Now()
+	data, err := format(m.From, msg, now)
+	if err != nil {
+		return err
+	}
+
+	if m.Dir == "" {
+		log.Printf("email ke %s:\n%s", msg.To, data)
+		return nil
+	}
+
+	if err := os.MkdirAll(m.Dir, os.ModePerm); err != nil {
+		return err
+	}
+	b := make([]byte, 4)
+	rand.Read(b)
+	path := filepath.Join(m.Dir, fmt.Sprintf("%s-%s.eml", now.Format("20060102-150405"), hex.EncodeToString(b)))
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return err
+	}
+
+	log.Printf("email ke %s disimpan di %s", msg.To, path)
+	return nil
+}
@@ -0,0 +1,46 @@
+// Package mail mengirim email transaksional (reset password, verifikasi).
+package mail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+)
+
+// Message adalah email teks biasa
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer mengirim email. SMTPMailer untuk production, FileMailer untuk
+// development dan test.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// format menyusun email RFC 5322 lengkap dengan header
+func format(from string, msg Message, now time.Time) ([]byte, error) {
+	// Header yang memuat baris baru bisa dipakai menyisipkan header lain
+	for _, v := range []string{from, msg.To, msg.Subject} {
+		if strings.ContainsAny(v, "\r\n") {
+			return nil, errors.New("header email tidak boleh memuat baris baru")
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return b.Bytes(), nil
+}
@@ -0,0 +1,51 @@
+package mail
+
+import (
+	"context"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+)
+
+// SMTPMailer mengirim email lewat server SMTP. Koneksi memakai STARTTLS jika
+// server mendukungnya; login hanya dilakukan jika User diisi.
+type SMTPMailer struct {
+	Host string
+	Port string
+	User string
+	Pass string
+	From string
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	data, err := format(m.From, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// Envelope hanya berisi alamat, tanpa nama tampilan
+	from, err := mail.ParseAddress(m.From)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.User != "" {
+		auth = smtp.PlainAuth("", m.User, m.Pass, m.Host)
+	}
+
+	// smtp.SendMail tidak menerima context, jadi dijalankan terpisah supaya
+	// pemanggil tidak tertahan saat ctx selesai
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(net.JoinHostPort(m.Host, m.Port), auth, from.Address, []string{msg.To}, data)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,72 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// lockName adalah nama advisory lock MySQL untuk proses migrasi
+const lockName = "evermos_schema_migrations"
+
+// lockTimeout adalah lama menunggu instance lain selesai migrasi (detik)
+const lockTimeout = 60
+
+// dialect berisi perbedaan per database. lock mengembalikan fungsi release
+// yang menerima error hasil migrasi.
+type dialect struct {
+	createTable string
+	lock        func(ctx context.Context, conn *sql.Conn) (release func(err error) error, err error)
+}
+
+var dialects = map[string]dialect{
+	"mysql": {
+		createTable: "CREATE TABLE IF NOT EXISTS schema_migrations (" +
+			"version bigint NOT NULL PRIMARY KEY, " +
+			"name varchar(255) NOT NULL, " +
+			"applied_at datetime(3) NOT NULL)",
+		lock: mysqlLock,
+	},
+	"sqlite": {
+		createTable: "CREATE TABLE IF NOT EXISTS schema_migrations (" +
+			"version integer NOT NULL PRIMARY KEY, " +
+			"name text NOT NULL, " +
+			"applied_at datetime NOT NULL)",
+		lock: sqliteLock,
+	},
+}
+
+// mysqlLock memakai GET_LOCK yang terikat ke koneksi. DDL MySQL tidak bisa
+// di-rollback, jadi migrasi yang gagal di tengah harus diperbaiki manual.
+func mysqlLock(ctx context.Context, conn *sql.Conn) (func(error) error, error) {
+	var ok sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, lockTimeout).Scan(&ok); err != nil {
+		return nil, err
+	}
+	if !ok.Valid || ok.Int64 != 1 {
+		return nil, errors.New("timeout menunggu migrasi dari instance lain")
+	}
+
+	return func(error) error {
+		_, err := conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", lockName)
+		return err
+	}, nil
+}
+
+// sqliteLock membuka transaksi BEGIN IMMEDIATE yang mengunci database untuk
+// penulis lain. DDL SQLite transaksional, jadi seluruh run di-commit atau
+// di-rollback bersama.
+func sqliteLock(ctx context.Context, conn *sql.Conn) (func(error) error, error) {
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return nil, err
+	}
+
+	return func(err error) error {
+		if err != nil {
+			_, rerr := conn.ExecContext(context.Background(), "ROLLBACK")
+			return rerr
+		}
+		_, cerr := conn.ExecContext(context.Background(), "COMMIT")
+		return cerr
+	}, nil
+}
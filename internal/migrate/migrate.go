@@ -0,0 +1,290 @@
+// Package migrate menjalankan migrasi SQL berversi yang di-embed ke binary.
+//
+// File migrasi ada di migrations/<driver>/ dengan nama
+// <versi>_<nama>.up.sql dan <versi>_<nama>.down.sql. Setiap statement harus
+// diakhiri ";" di akhir baris. Versi yang sudah diterapkan dicatat di tabel
+// schema_migrations, dan setiap run memegang advisory lock supaya beberapa
+// instance yang start bersamaan tidak menjalankan migrasi yang sama dua kali.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations
+var files embed.FS
+
+// Migration adalah satu versi skema beserta SQL naik dan turunnya
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+func (m Migration) String() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// Status adalah migrasi beserta waktu diterapkan (nil jika belum)
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	db         *sql.DB
+	dialect    dialect
+	migrations []Migration
+}
+
+// New membuat Migrator untuk driver (mysql atau sqlite) dari file yang di-embed
+func New(db *sql.DB, driver string) (*Migrator, error) {
+	d, ok := dialects[driver]
+	if !ok {
+		return nil, fmt.Errorf("migrasi untuk driver %q tidak tersedia", driver)
+	}
+
+	migrations, err := load(files, path.Join("migrations", driver))
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, dialect: d, migrations: migrations}, nil
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+func load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, e := range entries {
+		m := fileName.FindStringSubmatch(e.Name())
+		if m == nil {
+			return nil, fmt.Errorf("nama file migrasi tidak valid: %s", e.Name())
+		}
+		version, _ := strconv.ParseInt(m[1], 10, 64)
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		}
+		if mig.Name != m[2] {
+			return nil, fmt.Errorf("versi %d dipakai oleh dua migrasi: %s dan %s", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(data)
+		} else {
+			mig.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" || mig.Down == "" {
+			return nil, fmt.Errorf("migrasi %s harus punya file up dan down", mig)
+		}
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Up menerapkan semua migrasi yang belum diterapkan, urut dari versi terkecil
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			if err := run(ctx, conn, mig.Up); err != nil {
+				return fmt.Errorf("migrasi %s gagal: %w", mig, err)
+			}
+			if _, err := conn.ExecContext(ctx,
+				"INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+				mig.Version, mig.Name, time.Now().UTC()); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down membatalkan steps migrasi terakhir yang sudah diterapkan
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			if err := run(ctx, conn, mig.Down); err != nil {
+				return fmt.Errorf("rollback %s gagal: %w", mig, err)
+			}
+			if _, err := conn.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ?", mig.Version); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status menampilkan semua migrasi dan kapan diterapkan
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var result []Status
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			s := Status{Migration: mig}
+			if at, ok := applied[mig.Version]; ok {
+				s.AppliedAt = &at
+			}
+			result = append(result, s)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// Pending mengembalikan jumlah migrasi yang belum diterapkan
+func (m *Migrator) Pending(ctx context.Context) (int, error) {
+	statuses, err := m.Status(ctx)
+	pending := 0
+	for _, s := range statuses {
+		if s.AppliedAt == nil {
+			pending++
+		}
+	}
+	return pending, err
+}
+
+// Version mengembalikan versi migrasi terakhir yang sudah diterapkan (0 jika
+// belum ada). Tidak memegang lock supaya murah dipanggil dari health check.
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	var version sql.NullInt64
+	err := m.db.QueryRowContext(ctx, "SELECT MAX(version) FROM schema_migrations").Scan(&version)
+	return version.Int64, err
+}
+
+// Latest adalah versi migrasi terbaru yang ikut di binary
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// locked menjalankan fn di satu koneksi yang memegang advisory lock, setelah
+// memastikan tabel schema_migrations ada
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	release, err := m.dialect.lock(ctx, conn)
+	if err != nil {
+		return fmt.Errorf("gagal mengambil lock migrasi: %w", err)
+	}
+	defer func() {
+		if rerr := release(err); err == nil {
+			err = rerr
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, m.dialect.createTable); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+	return applied, rows.Err()
+}
+
+// run menjalankan isi file migrasi statement per statement
+func run(ctx context.Context, conn *sql.Conn, script string) error {
+	for _, stmt := range statements(script) {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("%w\n%s", err, stmt)
+		}
+	}
+	return nil
+}
+
+// statements memecah script per ";" di akhir baris dan membuang baris komentar
+func statements(script string) []string {
+	var result []string
+	var cur strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		cur.WriteString(line)
+		cur.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			result = append(result, strings.TrimSuffix(strings.TrimSpace(cur.String()), ";"))
+			cur.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(cur.String()); rest != "" {
+		result = append(result, rest)
+	}
+	return result
+}
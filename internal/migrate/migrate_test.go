@@ -0,0 +1,150 @@
+package migrate_test
+
+import (
+	"context"
+	"go-evermos/config"
+	"go-evermos/internal/entities"
+	"go-evermos/internal/migrate"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// openSQLite membuka database SQLite kosong di memori beserta migratornya
+func openSQLite(t *testing.T) (*gorm.DB, *migrate.Migrator) {
+	t.Helper()
+
+	db, err := config.OpenSQLite(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	m, err := migrate.New(sqlDB, config.DriverSQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, m
+}
+
+func TestOpeningStockMigration(t *testing.T) {
+	ctx := context.Background()
+	db, m := openSQLite(t)
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	// kembali ke skema sebelum 0011 untuk meniru database lama
+	beforeOpening := int(m.Latest() - 10)
+	if _, err := m.Down(ctx, beforeOpening); err != nil {
+		t.Fatal(err)
+	}
+
+	user := entities.User{Nama: "penjual", KataSandi: "hash", Notelp: "+6281100000001", Email: "penjual@x.com", TanggalLahir: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+	store := entities.Store{IDUser: user.ID}
+	category := entities.Category{NamaCategory: "Umum"}
+	if err := db.Create(&store).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&category).Error; err != nil {
+		t.Fatal(err)
+	}
+	newProduct := func(stok int) entities.Product {
+		p := entities.Product{NamaProduk: "Produk", Slug: "produk", HargaReseller: "700", HargaKonsumen: "1000", Stok: stok, IDToko: store.ID, IDCategory: category.ID}
+		if err := db.Create(&p).Error; err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	legacy := newProduct(8)
+	tracked := newProduct(5)
+	if err := db.Create(&entities.StockMovement{IDProduk: tracked.ID, Tipe: entities.StockMovementAdjustment, Jumlah: 5, StokSesudah: 5}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var movements []entities.StockMovement
+	if err := db.Order("id").Find(&movements).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(movements) != 2 {
+		t.Fatalf("%d mutasi, seharusnya 2 (satu saldo awal untuk produk lama)", len(movements))
+	}
+	opening := movements[1]
+	if opening.IDProduk != legacy.ID || opening.Jumlah != 8 || opening.StokSebelum != 0 || opening.StokSesudah != 8 {
+		t.Errorf("saldo awal %+v, seharusnya produk %d dengan jumlah 8", opening, legacy.ID)
+	}
+
+	if _, err := m.Down(ctx, beforeOpening); err != nil {
+		t.Fatal(err)
+	}
+	var count int64
+	db.Model(&entities.StockMovement{}).Count(&count)
+	if count != 1 {
+		t.Errorf("%d mutasi setelah rollback, seharusnya 1", count)
+	}
+}
+
+// schema mengembalikan definisi semua tabel dan index selain milik SQLite dan
+// tabel pencatat migrasi
+func schema(t *testing.T, db *gorm.DB) []string {
+	t.Helper()
+
+	var defs []string
+	err := db.Raw("SELECT type || ' ' || name || ': ' || COALESCE(sql, '') FROM sqlite_master " +
+		"WHERE name NOT LIKE 'sqlite_%' AND name <> 'schema_migrations' ORDER BY type, name").Scan(&defs).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	return defs
+}
+
+func TestMigrateRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	db, m := openSQLite(t)
+
+	applied, err := m.Up(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	full := schema(t, db)
+	if version, _ := m.Version(ctx); version != m.Latest() {
+		t.Fatalf("versi %d setelah up, seharusnya %d", version, m.Latest())
+	}
+
+	// rollback satu per satu supaya migrasi yang gagal di-down mudah dikenali
+	for i := len(applied) - 1; i >= 0; i-- {
+		done, err := m.Down(ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(done) != 1 || done[0].Version != applied[i].Version {
+			t.Fatalf("down membatalkan %v, seharusnya %s", done, applied[i])
+		}
+	}
+	if version, _ := m.Version(ctx); version != 0 {
+		t.Errorf("versi %d setelah semua migrasi dibatalkan", version)
+	}
+	if got := schema(t, db); len(got) > 0 {
+		t.Errorf("skema tersisa setelah down:\n%s", strings.Join(got, "\n"))
+	}
+
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := schema(t, db); !slices.Equal(got, full) {
+		t.Errorf("skema setelah up ulang berbeda:\n%s\n\nseharusnya:\n%s", strings.Join(got, "\n"), strings.Join(full, "\n"))
+	}
+}
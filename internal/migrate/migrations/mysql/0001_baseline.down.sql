@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS `LanggananStok`;
+DROP TABLE IF EXISTS `Notifikasi`;
+DROP TABLE IF EXISTS `MutasiStok`;
+DROP TABLE IF EXISTS `ImportJob`;
+DROP TABLE IF EXISTS `TrxDetail`;
+DROP TABLE IF EXISTS `Trx`;
+DROP TABLE IF EXISTS `Alamat`;
+DROP TABLE IF EXISTS `FotoProduk`;
+DROP TABLE IF EXISTS `ProdukLog`;
+DROP TABLE IF EXISTS `Produk`;
+DROP TABLE IF EXISTS `Toko`;
+DROP TABLE IF EXISTS `Category`;
+DROP TABLE IF EXISTS `Users`;
@@ -0,0 +1,222 @@
+-- Baseline: skema yang sebelumnya dibuat oleh AutoMigrate. Memakai
+-- IF NOT EXISTS supaya database lama bisa langsung diadopsi ke versi 1.
+
+CREATE TABLE IF NOT EXISTS `Users` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `nama` varchar(255) NOT NULL,
+  `kata_sandi` varchar(255) NOT NULL,
+  `notelp` varchar(255) NOT NULL,
+  `tanggal_lahir` date NOT NULL,
+  `jenis_kelamin` varchar(255) NOT NULL,
+  `tentang` text DEFAULT null,
+  `pekerjaan` varchar(255) NOT NULL,
+  `email` varchar(255) NOT NULL,
+  `id_provinsi` varchar(255) NOT NULL,
+  `id_kota` varchar(255) NOT NULL,
+  `is_admin` boolean DEFAULT false,
+  PRIMARY KEY (`id`),
+  INDEX `idx_Users_deleted_at` (`deleted_at`),
+  UNIQUE INDEX `idx_notelp` (`notelp`),
+  UNIQUE INDEX `idx_email` (`email`)
+);
+
+CREATE TABLE IF NOT EXISTS `Category` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `nama_category` varchar(255) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_Category_deleted_at` (`deleted_at`)
+);
+
+CREATE TABLE IF NOT EXISTS `Toko` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `id_user` bigint unsigned NOT NULL,
+  `nama_toko` varchar(255) DEFAULT null,
+  `url_foto` varchar(255) DEFAULT null,
+  PRIMARY KEY (`id`),
+  INDEX `idx_Toko_deleted_at` (`deleted_at`)
+);
+
+CREATE TABLE IF NOT EXISTS `Produk` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `nama_produk` varchar(255) NOT NULL,
+  `slug` varchar(255) NOT NULL,
+  `harga_reseller` varchar(255) NOT NULL,
+  `harga_konsumen` varchar(255) NOT NULL,
+  `stok` bigint NOT NULL,
+  `stok_dipesan` bigint NOT NULL DEFAULT 0,
+  `stok_minimum` bigint NOT NULL DEFAULT 0,
+  `deskripsi` text DEFAULT null,
+  `status` varchar(20) NOT NULL DEFAULT 'active',
+  `alasan_ban` text DEFAULT null,
+  `id_toko` bigint unsigned NOT NULL,
+  `id_category` bigint unsigned NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_Produk_deleted_at` (`deleted_at`),
+  INDEX `idx_Produk_status` (`status`),
+  CONSTRAINT `fk_Produk_store` FOREIGN KEY (`id_toko`) REFERENCES `Toko`(`id`),
+  CONSTRAINT `fk_Produk_category` FOREIGN KEY (`id_category`) REFERENCES `Category`(`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `ProdukLog` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `id_produk` bigint unsigned NOT NULL,
+  `nama_produk` varchar(255) NOT NULL,
+  `slug` varchar(255) NOT NULL,
+  `harga_reseller` varchar(255) NOT NULL,
+  `harga_konsumen` varchar(255) NOT NULL,
+  `deskripsi` text DEFAULT null,
+  `id_toko` bigint unsigned NOT NULL,
+  `id_category` bigint unsigned NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_ProdukLog_deleted_at` (`deleted_at`),
+  CONSTRAINT `fk_ProdukLog_store` FOREIGN KEY (`id_toko`) REFERENCES `Toko`(`id`),
+  CONSTRAINT `fk_ProdukLog_category` FOREIGN KEY (`id_category`) REFERENCES `Category`(`id`),
+  CONSTRAINT `fk_ProdukLog_product` FOREIGN KEY (`id_produk`) REFERENCES `Produk`(`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `FotoProduk` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `id_produk` bigint unsigned NOT NULL,
+  `url` varchar(255) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_FotoProduk_deleted_at` (`deleted_at`),
+  CONSTRAINT `fk_Produk_product_picture` FOREIGN KEY (`id_produk`) REFERENCES `Produk`(`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `Alamat` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `id_user` bigint unsigned NOT NULL,
+  `judul_alamat` varchar(255) NOT NULL,
+  `nama_penerima` varchar(255) NOT NULL,
+  `no_telp` varchar(255) NOT NULL,
+  `detail_alamat` varchar(255) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_Alamat_deleted_at` (`deleted_at`),
+  CONSTRAINT `fk_Alamat_user` FOREIGN KEY (`id_user`) REFERENCES `Users`(`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `Trx` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `id_user` bigint unsigned NOT NULL,
+  `alamat_pengiriman` bigint unsigned NOT NULL,
+  `harga_total` bigint NOT NULL,
+  `kode_invoice` varchar(255) NOT NULL,
+  `method_bayar` varchar(255) NOT NULL,
+  `status_bayar` varchar(20) NOT NULL DEFAULT 'paid',
+  `batas_bayar` datetime(3) NULL,
+  `tanggal_bayar` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_Trx_deleted_at` (`deleted_at`),
+  INDEX `idx_Trx_status_bayar` (`status_bayar`),
+  CONSTRAINT `fk_Trx_user` FOREIGN KEY (`id_user`) REFERENCES `Users`(`id`),
+  CONSTRAINT `fk_Trx_address` FOREIGN KEY (`alamat_pengiriman`) REFERENCES `Alamat`(`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `TrxDetail` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `id_trx` bigint unsigned NOT NULL,
+  `id_log_produk` bigint unsigned NOT NULL,
+  `id_toko` bigint unsigned NOT NULL,
+  `kuantitas` bigint NOT NULL,
+  `harga_total` bigint NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_TrxDetail_deleted_at` (`deleted_at`),
+  CONSTRAINT `fk_TrxDetail_store` FOREIGN KEY (`id_toko`) REFERENCES `Toko`(`id`),
+  CONSTRAINT `fk_TrxDetail_product_log` FOREIGN KEY (`id_log_produk`) REFERENCES `ProdukLog`(`id`),
+  CONSTRAINT `fk_Trx_trx_detail` FOREIGN KEY (`id_trx`) REFERENCES `Trx`(`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `ImportJob` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `id_toko` bigint unsigned NOT NULL,
+  `id_user` bigint unsigned NOT NULL,
+  `nama_file` varchar(255) NOT NULL,
+  `format` varchar(10) NOT NULL,
+  `dry_run` boolean DEFAULT false,
+  `status` varchar(20) NOT NULL DEFAULT 'pending',
+  `total_baris` bigint NOT NULL DEFAULT 0,
+  `baris_sukses` bigint NOT NULL DEFAULT 0,
+  `baris_gagal` bigint NOT NULL DEFAULT 0,
+  `pesan` text DEFAULT null,
+  `errors` text,
+  PRIMARY KEY (`id`),
+  INDEX `idx_ImportJob_deleted_at` (`deleted_at`),
+  INDEX `idx_ImportJob_id_toko` (`id_toko`)
+);
+
+CREATE TABLE IF NOT EXISTS `MutasiStok` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `id_produk` bigint unsigned NOT NULL,
+  `tipe` varchar(20) NOT NULL,
+  `jumlah` bigint NOT NULL,
+  `stok_sebelum` bigint NOT NULL,
+  `stok_sesudah` bigint NOT NULL,
+  `id_trx` bigint unsigned DEFAULT null,
+  `id_user` bigint unsigned DEFAULT null,
+  `catatan` text DEFAULT null,
+  PRIMARY KEY (`id`),
+  INDEX `idx_MutasiStok_deleted_at` (`deleted_at`),
+  INDEX `idx_MutasiStok_id_produk` (`id_produk`),
+  INDEX `idx_MutasiStok_id_trx` (`id_trx`)
+);
+
+CREATE TABLE IF NOT EXISTS `Notifikasi` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `id_user` bigint unsigned NOT NULL,
+  `tipe` varchar(30) NOT NULL,
+  `judul` varchar(255) NOT NULL,
+  `pesan` text NOT NULL,
+  `id_produk` bigint unsigned DEFAULT null,
+  `dibaca_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_Notifikasi_deleted_at` (`deleted_at`),
+  INDEX `idx_Notifikasi_id_user` (`id_user`)
+);
+
+CREATE TABLE IF NOT EXISTS `LanggananStok` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `id_user` bigint unsigned NOT NULL,
+  `id_produk` bigint unsigned NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_LanggananStok_deleted_at` (`deleted_at`),
+  UNIQUE INDEX `idx_langganan_user_produk` (`id_user`,`id_produk`)
+);
@@ -0,0 +1,13 @@
+ALTER TABLE `Users` MODIFY `created_at` datetime(3) NULL, MODIFY `updated_at` datetime(3) NULL;
+ALTER TABLE `Category` MODIFY `created_at` datetime(3) NULL, MODIFY `updated_at` datetime(3) NULL;
+ALTER TABLE `Toko` MODIFY `created_at` datetime(3) NULL, MODIFY `updated_at` datetime(3) NULL;
+ALTER TABLE `Produk` MODIFY `created_at` datetime(3) NULL, MODIFY `updated_at` datetime(3) NULL;
+ALTER TABLE `ProdukLog` MODIFY `created_at` datetime(3) NULL, MODIFY `updated_at` datetime(3) NULL;
+ALTER TABLE `FotoProduk` MODIFY `created_at` datetime(3) NULL, MODIFY `updated_at` datetime(3) NULL;
+ALTER TABLE `Alamat` MODIFY `created_at` datetime(3) NULL, MODIFY `updated_at` datetime(3) NULL;
+ALTER TABLE `Trx` MODIFY `created_at` datetime(3) NULL, MODIFY `updated_at` datetime(3) NULL;
+ALTER TABLE `TrxDetail` MODIFY `created_at` datetime(3) NULL, MODIFY `updated_at` datetime(3) NULL;
+ALTER TABLE `ImportJob` MODIFY `created_at` datetime(3) NULL, MODIFY `updated_at` datetime(3) NULL;
+ALTER TABLE `MutasiStok` MODIFY `created_at` datetime(3) NULL, MODIFY `updated_at` datetime(3) NULL;
+ALTER TABLE `Notifikasi` MODIFY `created_at` datetime(3) NULL, MODIFY `updated_at` datetime(3) NULL;
+ALTER TABLE `LanggananStok` MODIFY `created_at` datetime(3) NULL, MODIFY `updated_at` datetime(3) NULL;
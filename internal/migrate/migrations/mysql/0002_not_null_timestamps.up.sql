@@ -0,0 +1,54 @@
+-- created_at/updated_at sekarang wajib terisi (entities.Model). Baris lama
+-- yang kosong diisi dari kolom lainnya, atau waktu migrasi jika keduanya kosong.
+
+UPDATE `Users` SET `created_at` = COALESCE(`updated_at`, NOW(3)) WHERE `created_at` IS NULL;
+UPDATE `Users` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+ALTER TABLE `Users` MODIFY `created_at` datetime(3) NOT NULL, MODIFY `updated_at` datetime(3) NOT NULL;
+
+UPDATE `Category` SET `created_at` = COALESCE(`updated_at`, NOW(3)) WHERE `created_at` IS NULL;
+UPDATE `Category` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+ALTER TABLE `Category` MODIFY `created_at` datetime(3) NOT NULL, MODIFY `updated_at` datetime(3) NOT NULL;
+
+UPDATE `Toko` SET `created_at` = COALESCE(`updated_at`, NOW(3)) WHERE `created_at` IS NULL;
+UPDATE `Toko` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+ALTER TABLE `Toko` MODIFY `created_at` datetime(3) NOT NULL, MODIFY `updated_at` datetime(3) NOT NULL;
+
+UPDATE `Produk` SET `created_at` = COALESCE(`updated_at`, NOW(3)) WHERE `created_at` IS NULL;
+UPDATE `Produk` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+ALTER TABLE `Produk` MODIFY `created_at` datetime(3) NOT NULL, MODIFY `updated_at` datetime(3) NOT NULL;
+
+UPDATE `ProdukLog` SET `created_at` = COALESCE(`updated_at`, NOW(3)) WHERE `created_at` IS NULL;
+UPDATE `ProdukLog` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+ALTER TABLE `ProdukLog` MODIFY `created_at` datetime(3) NOT NULL, MODIFY `updated_at` datetime(3) NOT NULL;
+
+UPDATE `FotoProduk` SET `created_at` = COALESCE(`updated_at`, NOW(3)) WHERE `created_at` IS NULL;
+UPDATE `FotoProduk` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+ALTER TABLE `FotoProduk` MODIFY `created_at` datetime(3) NOT NULL, MODIFY `updated_at` datetime(3) NOT NULL;
+
+UPDATE `Alamat` SET `created_at` = COALESCE(`updated_at`, NOW(3)) WHERE `created_at` IS NULL;
+UPDATE `Alamat` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+ALTER TABLE `Alamat` MODIFY `created_at` datetime(3) NOT NULL, MODIFY `updated_at` datetime(3) NOT NULL;
+
+UPDATE `Trx` SET `created_at` = COALESCE(`updated_at`, NOW(3)) WHERE `created_at` IS NULL;
+UPDATE `Trx` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+ALTER TABLE `Trx` MODIFY `created_at` datetime(3) NOT NULL, MODIFY `updated_at` datetime(3) NOT NULL;
+
+UPDATE `TrxDetail` SET `created_at` = COALESCE(`updated_at`, NOW(3)) WHERE `created_at` IS NULL;
+UPDATE `TrxDetail` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+ALTER TABLE `TrxDetail` MODIFY `created_at` datetime(3) NOT NULL, MODIFY `updated_at` datetime(3) NOT NULL;
+
+UPDATE `ImportJob` SET `created_at` = COALESCE(`updated_at`, NOW(3)) WHERE `created_at` IS NULL;
+UPDATE `ImportJob` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+ALTER TABLE `ImportJob` MODIFY `created_at` datetime(3) NOT NULL, MODIFY `updated_at` datetime(3) NOT NULL;
+
+UPDATE `MutasiStok` SET `created_at` = COALESCE(`updated_at`, NOW(3)) WHERE `created_at` IS NULL;
+UPDATE `MutasiStok` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+ALTER TABLE `MutasiStok` MODIFY `created_at` datetime(3) NOT NULL, MODIFY `updated_at` datetime(3) NOT NULL;
+
+UPDATE `Notifikasi` SET `created_at` = COALESCE(`updated_at`, NOW(3)) WHERE `created_at` IS NULL;
+UPDATE `Notifikasi` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+ALTER TABLE `Notifikasi` MODIFY `created_at` datetime(3) NOT NULL, MODIFY `updated_at` datetime(3) NOT NULL;
+
+UPDATE `LanggananStok` SET `created_at` = COALESCE(`updated_at`, NOW(3)) WHERE `created_at` IS NULL;
+UPDATE `LanggananStok` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+ALTER TABLE `LanggananStok` MODIFY `created_at` datetime(3) NOT NULL, MODIFY `updated_at` datetime(3) NOT NULL;
@@ -0,0 +1,20 @@
+-- Refresh token yang dirotasi setiap kali dipakai. Hanya hash token yang
+-- disimpan; family mengelompokkan semua token dari satu login.
+
+CREATE TABLE `RefreshToken` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NOT NULL,
+  `updated_at` datetime(3) NOT NULL,
+  `deleted_at` datetime(3) NULL,
+  `id_user` bigint unsigned NOT NULL,
+  `family` varchar(64) NOT NULL,
+  `token_hash` varchar(64) NOT NULL,
+  `expires_at` datetime(3) NOT NULL,
+  `rotated_at` datetime(3) NULL,
+  `revoked_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_RefreshToken_deleted_at` (`deleted_at`),
+  INDEX `idx_RefreshToken_id_user` (`id_user`),
+  INDEX `idx_RefreshToken_family` (`family`),
+  UNIQUE INDEX `idx_RefreshToken_token_hash` (`token_hash`)
+);
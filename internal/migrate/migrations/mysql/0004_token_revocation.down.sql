@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `RevokedToken`;
+ALTER TABLE `Users` DROP COLUMN `banned_at`, DROP COLUMN `alasan_ban`, DROP COLUMN `token_version`;
@@ -0,0 +1,22 @@
+-- Pencabutan token: access token tunggal (logout) lewat jti, dan semua sesi
+-- user lewat Users.token_version (logout semua, ganti password, blokir).
+
+ALTER TABLE `Users`
+  ADD COLUMN `banned_at` datetime(3) NULL,
+  ADD COLUMN `alasan_ban` text DEFAULT null,
+  ADD COLUMN `token_version` bigint unsigned NOT NULL DEFAULT 0;
+
+CREATE TABLE `RevokedToken` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NOT NULL,
+  `updated_at` datetime(3) NOT NULL,
+  `deleted_at` datetime(3) NULL,
+  `jti` varchar(64) NOT NULL,
+  `id_user` bigint unsigned NOT NULL,
+  `expires_at` datetime(3) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_RevokedToken_deleted_at` (`deleted_at`),
+  UNIQUE INDEX `idx_RevokedToken_jti` (`jti`),
+  INDEX `idx_RevokedToken_id_user` (`id_user`),
+  INDEX `idx_RevokedToken_expires_at` (`expires_at`)
+);
@@ -0,0 +1,17 @@
+-- Token reset password. Hanya hash yang disimpan; used_at terisi setelah
+-- token dipakai atau dibatalkan oleh permintaan reset yang lebih baru.
+
+CREATE TABLE `PasswordReset` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NOT NULL,
+  `updated_at` datetime(3) NOT NULL,
+  `deleted_at` datetime(3) NULL,
+  `id_user` bigint unsigned NOT NULL,
+  `token_hash` varchar(64) NOT NULL,
+  `expires_at` datetime(3) NOT NULL,
+  `used_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_PasswordReset_deleted_at` (`deleted_at`),
+  INDEX `idx_PasswordReset_id_user` (`id_user`),
+  UNIQUE INDEX `idx_PasswordReset_token_hash` (`token_hash`)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `VerificationCode`;
+ALTER TABLE `Users` DROP COLUMN `email_verified_at`, DROP COLUMN `phone_verified_at`;
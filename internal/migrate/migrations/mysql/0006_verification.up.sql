@@ -0,0 +1,25 @@
+-- Verifikasi email dan no telp. User yang sudah ada sebelum fitur ini
+-- dianggap terverifikasi supaya tidak tiba-tiba tidak bisa checkout.
+
+ALTER TABLE `Users`
+  ADD COLUMN `email_verified_at` datetime(3) NULL,
+  ADD COLUMN `phone_verified_at` datetime(3) NULL;
+
+UPDATE `Users` SET `email_verified_at` = `created_at`, `phone_verified_at` = `created_at`;
+
+CREATE TABLE `VerificationCode` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NOT NULL,
+  `updated_at` datetime(3) NOT NULL,
+  `deleted_at` datetime(3) NULL,
+  `id_user` bigint unsigned NOT NULL,
+  `channel` varchar(10) NOT NULL,
+  `target` varchar(255) NOT NULL,
+  `code_hash` varchar(64) NOT NULL,
+  `expires_at` datetime(3) NOT NULL,
+  `attempts` bigint NOT NULL DEFAULT 0,
+  `used_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_VerificationCode_deleted_at` (`deleted_at`),
+  INDEX `idx_VerificationCode_user_channel` (`id_user`, `channel`)
+);
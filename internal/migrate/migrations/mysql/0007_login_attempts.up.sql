@@ -0,0 +1,16 @@
+CREATE TABLE `LoginAttempt` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NOT NULL,
+  `updated_at` datetime(3) NOT NULL,
+  `deleted_at` datetime(3) NULL,
+  `email` varchar(255) NOT NULL,
+  `id_user` bigint unsigned NULL,
+  `ip` varchar(45) NOT NULL,
+  `user_agent` varchar(255) NOT NULL,
+  `outcome` varchar(10) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_LoginAttempt_deleted_at` (`deleted_at`),
+  INDEX `idx_LoginAttempt_id_user` (`id_user`),
+  INDEX `idx_LoginAttempt_email_created` (`email`, `created_at`),
+  INDEX `idx_LoginAttempt_ip_created` (`ip`, `created_at`)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS `RecoveryCode`;
+DROP TABLE IF EXISTS `LoginChallenge`;
+ALTER TABLE `Users` DROP COLUMN `totp_secret`, DROP COLUMN `totp_enabled_at`, DROP COLUMN `totp_last_step`;
@@ -0,0 +1,33 @@
+ALTER TABLE `Users`
+  ADD COLUMN `totp_secret` varchar(64) NULL,
+  ADD COLUMN `totp_enabled_at` datetime(3) NULL,
+  ADD COLUMN `totp_last_step` bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE `LoginChallenge` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NOT NULL,
+  `updated_at` datetime(3) NOT NULL,
+  `deleted_at` datetime(3) NULL,
+  `id_user` bigint unsigned NOT NULL,
+  `token_hash` varchar(64) NOT NULL,
+  `expires_at` datetime(3) NOT NULL,
+  `attempts` bigint NOT NULL DEFAULT 0,
+  `used_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_LoginChallenge_deleted_at` (`deleted_at`),
+  INDEX `idx_LoginChallenge_id_user` (`id_user`),
+  UNIQUE INDEX `idx_LoginChallenge_token_hash` (`token_hash`)
+);
+
+CREATE TABLE `RecoveryCode` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NOT NULL,
+  `updated_at` datetime(3) NOT NULL,
+  `deleted_at` datetime(3) NULL,
+  `id_user` bigint unsigned NOT NULL,
+  `code_hash` varchar(64) NOT NULL,
+  `used_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_RecoveryCode_deleted_at` (`deleted_at`),
+  INDEX `idx_RecoveryCode_id_user` (`id_user`)
+);
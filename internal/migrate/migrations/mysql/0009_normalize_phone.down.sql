@@ -0,0 +1,2 @@
+-- Format no telp asli tidak disimpan, jadi normalisasi tidak bisa dibatalkan.
+-- Format E.164 tetap valid untuk versi sebelumnya.
@@ -0,0 +1,10 @@
+-- Samakan no telp lama ke format E.164 (+62...) seperti yang dipakai
+-- Register dan login. Nomor yang hasilnya bentrok dengan user lain
+-- dibiarkan apa adanya (IGNORE).
+
+UPDATE IGNORE `Users`
+SET `notelp` = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(`notelp`, ' ', ''), '-', ''), '.', ''), '(', ''), ')', '');
+
+UPDATE IGNORE `Users` SET `notelp` = CONCAT('+62', SUBSTRING(`notelp`, 2)) WHERE `notelp` LIKE '0%';
+UPDATE IGNORE `Users` SET `notelp` = CONCAT('+', `notelp`) WHERE `notelp` LIKE '62%';
+UPDATE IGNORE `Users` SET `notelp` = CONCAT('+62', `notelp`) WHERE `notelp` LIKE '8%';
@@ -0,0 +1,18 @@
+CREATE TABLE `UserRole` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NOT NULL,
+  `updated_at` datetime(3) NOT NULL,
+  `deleted_at` datetime(3) NULL,
+  `id_user` bigint unsigned NOT NULL,
+  `role` varchar(50) NOT NULL,
+  `id_pemberi` bigint unsigned NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_UserRole_deleted_at` (`deleted_at`),
+  UNIQUE INDEX `idx_UserRole_user_role` (`id_user`, `role`)
+);
+
+-- flag is_admin diganti role admin
+INSERT INTO `UserRole` (`created_at`, `updated_at`, `id_user`, `role`)
+SELECT NOW(3), NOW(3), `id`, 'admin' FROM `Users` WHERE `is_admin` = true;
+
+ALTER TABLE `Users` DROP COLUMN `is_admin`;
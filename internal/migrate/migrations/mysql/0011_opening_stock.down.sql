@@ -0,0 +1 @@
+DELETE FROM `MutasiStok` WHERE `catatan` = 'Stok awal (migrasi)';
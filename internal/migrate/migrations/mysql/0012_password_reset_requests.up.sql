@@ -0,0 +1,15 @@
+-- Setiap permintaan lupa password, untuk membatasi permintaan per email dan
+-- per IP tanpa membedakan email terdaftar atau tidak.
+
+CREATE TABLE `PasswordResetRequest` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NOT NULL,
+  `updated_at` datetime(3) NOT NULL,
+  `deleted_at` datetime(3) NULL,
+  `email` varchar(255) NOT NULL,
+  `ip` varchar(45) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_PasswordResetRequest_deleted_at` (`deleted_at`),
+  INDEX `idx_PasswordResetRequest_email_created` (`email`, `created_at`),
+  INDEX `idx_PasswordResetRequest_ip_created` (`ip`, `created_at`)
+);
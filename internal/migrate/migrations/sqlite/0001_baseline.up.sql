@@ -0,0 +1,209 @@
+-- Baseline: skema yang sebelumnya dibuat oleh AutoMigrate. Memakai
+-- IF NOT EXISTS supaya database lama bisa langsung diadopsi ke versi 1.
+
+CREATE TABLE IF NOT EXISTS `Users` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `nama` text NOT NULL,
+  `kata_sandi` text NOT NULL,
+  `notelp` text NOT NULL,
+  `tanggal_lahir` date NOT NULL,
+  `jenis_kelamin` text NOT NULL,
+  `tentang` text DEFAULT null,
+  `pekerjaan` text NOT NULL,
+  `email` text NOT NULL,
+  `id_provinsi` text NOT NULL,
+  `id_kota` text NOT NULL,
+  `is_admin` boolean DEFAULT false
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_email` ON `Users`(`email`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_notelp` ON `Users`(`notelp`);
+CREATE INDEX IF NOT EXISTS `idx_Users_deleted_at` ON `Users`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `Category` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `nama_category` text NOT NULL
+);
+CREATE INDEX IF NOT EXISTS `idx_Category_deleted_at` ON `Category`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `Toko` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `id_user` integer NOT NULL,
+  `nama_toko` text DEFAULT null,
+  `url_foto` text DEFAULT null
+);
+CREATE INDEX IF NOT EXISTS `idx_Toko_deleted_at` ON `Toko`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `Produk` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `nama_produk` text NOT NULL,
+  `slug` text NOT NULL,
+  `harga_reseller` text NOT NULL,
+  `harga_konsumen` text NOT NULL,
+  `stok` integer NOT NULL,
+  `stok_dipesan` integer NOT NULL DEFAULT 0,
+  `stok_minimum` integer NOT NULL DEFAULT 0,
+  `deskripsi` text DEFAULT null,
+  `status` text NOT NULL DEFAULT 'active',
+  `alasan_ban` text DEFAULT null,
+  `id_toko` integer NOT NULL,
+  `id_category` integer NOT NULL,
+  CONSTRAINT `fk_Produk_store` FOREIGN KEY (`id_toko`) REFERENCES `Toko`(`id`),
+  CONSTRAINT `fk_Produk_category` FOREIGN KEY (`id_category`) REFERENCES `Category`(`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_Produk_status` ON `Produk`(`status`);
+CREATE INDEX IF NOT EXISTS `idx_Produk_deleted_at` ON `Produk`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `ProdukLog` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `id_produk` integer NOT NULL,
+  `nama_produk` text NOT NULL,
+  `slug` text NOT NULL,
+  `harga_reseller` text NOT NULL,
+  `harga_konsumen` text NOT NULL,
+  `deskripsi` text DEFAULT null,
+  `id_toko` integer NOT NULL,
+  `id_category` integer NOT NULL,
+  CONSTRAINT `fk_ProdukLog_store` FOREIGN KEY (`id_toko`) REFERENCES `Toko`(`id`),
+  CONSTRAINT `fk_ProdukLog_category` FOREIGN KEY (`id_category`) REFERENCES `Category`(`id`),
+  CONSTRAINT `fk_ProdukLog_product` FOREIGN KEY (`id_produk`) REFERENCES `Produk`(`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_ProdukLog_deleted_at` ON `ProdukLog`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `FotoProduk` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `id_produk` integer NOT NULL,
+  `url` text NOT NULL,
+  CONSTRAINT `fk_Produk_product_picture` FOREIGN KEY (`id_produk`) REFERENCES `Produk`(`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_FotoProduk_deleted_at` ON `FotoProduk`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `Alamat` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `id_user` integer NOT NULL,
+  `judul_alamat` text NOT NULL,
+  `nama_penerima` text NOT NULL,
+  `no_telp` text NOT NULL,
+  `detail_alamat` text NOT NULL,
+  CONSTRAINT `fk_Alamat_user` FOREIGN KEY (`id_user`) REFERENCES `Users`(`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_Alamat_deleted_at` ON `Alamat`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `Trx` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `id_user` integer NOT NULL,
+  `alamat_pengiriman` integer NOT NULL,
+  `harga_total` integer NOT NULL,
+  `kode_invoice` text NOT NULL,
+  `method_bayar` text NOT NULL,
+  `status_bayar` text NOT NULL DEFAULT 'paid',
+  `batas_bayar` datetime,
+  `tanggal_bayar` datetime,
+  CONSTRAINT `fk_Trx_address` FOREIGN KEY (`alamat_pengiriman`) REFERENCES `Alamat`(`id`),
+  CONSTRAINT `fk_Trx_user` FOREIGN KEY (`id_user`) REFERENCES `Users`(`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_Trx_status_bayar` ON `Trx`(`status_bayar`);
+CREATE INDEX IF NOT EXISTS `idx_Trx_deleted_at` ON `Trx`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `TrxDetail` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `id_trx` integer NOT NULL,
+  `id_log_produk` integer NOT NULL,
+  `id_toko` integer NOT NULL,
+  `kuantitas` integer NOT NULL,
+  `harga_total` integer NOT NULL,
+  CONSTRAINT `fk_TrxDetail_product_log` FOREIGN KEY (`id_log_produk`) REFERENCES `ProdukLog`(`id`),
+  CONSTRAINT `fk_Trx_trx_detail` FOREIGN KEY (`id_trx`) REFERENCES `Trx`(`id`),
+  CONSTRAINT `fk_TrxDetail_store` FOREIGN KEY (`id_toko`) REFERENCES `Toko`(`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_TrxDetail_deleted_at` ON `TrxDetail`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `ImportJob` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `id_toko` integer NOT NULL,
+  `id_user` integer NOT NULL,
+  `nama_file` text NOT NULL,
+  `format` text NOT NULL,
+  `dry_run` boolean DEFAULT false,
+  `status` text NOT NULL DEFAULT 'pending',
+  `total_baris` integer NOT NULL DEFAULT 0,
+  `baris_sukses` integer NOT NULL DEFAULT 0,
+  `baris_gagal` integer NOT NULL DEFAULT 0,
+  `pesan` text DEFAULT null,
+  `errors` text
+);
+CREATE INDEX IF NOT EXISTS `idx_ImportJob_id_toko` ON `ImportJob`(`id_toko`);
+CREATE INDEX IF NOT EXISTS `idx_ImportJob_deleted_at` ON `ImportJob`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `MutasiStok` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `id_produk` integer NOT NULL,
+  `tipe` text NOT NULL,
+  `jumlah` integer NOT NULL,
+  `stok_sebelum` integer NOT NULL,
+  `stok_sesudah` integer NOT NULL,
+  `id_trx` integer DEFAULT null,
+  `id_user` integer DEFAULT null,
+  `catatan` text DEFAULT null
+);
+CREATE INDEX IF NOT EXISTS `idx_MutasiStok_id_trx` ON `MutasiStok`(`id_trx`);
+CREATE INDEX IF NOT EXISTS `idx_MutasiStok_id_produk` ON `MutasiStok`(`id_produk`);
+CREATE INDEX IF NOT EXISTS `idx_MutasiStok_deleted_at` ON `MutasiStok`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `Notifikasi` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `id_user` integer NOT NULL,
+  `tipe` text NOT NULL,
+  `judul` text NOT NULL,
+  `pesan` text NOT NULL,
+  `id_produk` integer DEFAULT null,
+  `dibaca_at` datetime
+);
+CREATE INDEX IF NOT EXISTS `idx_Notifikasi_id_user` ON `Notifikasi`(`id_user`);
+CREATE INDEX IF NOT EXISTS `idx_Notifikasi_deleted_at` ON `Notifikasi`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `LanggananStok` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `id_user` integer NOT NULL,
+  `id_produk` integer NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_langganan_user_produk` ON `LanggananStok`(`id_user`,`id_produk`);
+CREATE INDEX IF NOT EXISTS `idx_LanggananStok_deleted_at` ON `LanggananStok`(`deleted_at`);
@@ -0,0 +1 @@
+-- Tidak ada yang perlu dikembalikan: up hanya mengisi data yang kosong.
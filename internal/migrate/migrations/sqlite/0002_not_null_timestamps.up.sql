@@ -0,0 +1,46 @@
+-- created_at/updated_at sekarang wajib terisi (entities.Model). Baris lama
+-- yang kosong diisi dari kolom lainnya, atau waktu migrasi jika keduanya kosong.
+--
+-- Berbeda dengan MySQL, constraint NOT NULL tidak dipasang: SQLite hanya bisa
+-- mengubah kolom dengan membangun ulang tabel, dan itu butuh foreign_keys
+-- dimatikan yang tidak bisa dilakukan di dalam transaksi migrasi. Aplikasi
+-- selalu mengisi kedua kolom lewat GORM.
+
+UPDATE `Users` SET `created_at` = COALESCE(`updated_at`, CURRENT_TIMESTAMP) WHERE `created_at` IS NULL;
+UPDATE `Users` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+
+UPDATE `Category` SET `created_at` = COALESCE(`updated_at`, CURRENT_TIMESTAMP) WHERE `created_at` IS NULL;
+UPDATE `Category` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+
+UPDATE `Toko` SET `created_at` = COALESCE(`updated_at`, CURRENT_TIMESTAMP) WHERE `created_at` IS NULL;
+UPDATE `Toko` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+
+UPDATE `Produk` SET `created_at` = COALESCE(`updated_at`, CURRENT_TIMESTAMP) WHERE `created_at` IS NULL;
+UPDATE `Produk` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+
+UPDATE `ProdukLog` SET `created_at` = COALESCE(`updated_at`, CURRENT_TIMESTAMP) WHERE `created_at` IS NULL;
+UPDATE `ProdukLog` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+
+UPDATE `FotoProduk` SET `created_at` = COALESCE(`updated_at`, CURRENT_TIMESTAMP) WHERE `created_at` IS NULL;
+UPDATE `FotoProduk` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+
+UPDATE `Alamat` SET `created_at` = COALESCE(`updated_at`, CURRENT_TIMESTAMP) WHERE `created_at` IS NULL;
+UPDATE `Alamat` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+
+UPDATE `Trx` SET `created_at` = COALESCE(`updated_at`, CURRENT_TIMESTAMP) WHERE `created_at` IS NULL;
+UPDATE `Trx` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+
+UPDATE `TrxDetail` SET `created_at` = COALESCE(`updated_at`, CURRENT_TIMESTAMP) WHERE `created_at` IS NULL;
+UPDATE `TrxDetail` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+
+UPDATE `ImportJob` SET `created_at` = COALESCE(`updated_at`, CURRENT_TIMESTAMP) WHERE `created_at` IS NULL;
+UPDATE `ImportJob` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+
+UPDATE `MutasiStok` SET `created_at` = COALESCE(`updated_at`, CURRENT_TIMESTAMP) WHERE `created_at` IS NULL;
+UPDATE `MutasiStok` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+
+UPDATE `Notifikasi` SET `created_at` = COALESCE(`updated_at`, CURRENT_TIMESTAMP) WHERE `created_at` IS NULL;
+UPDATE `Notifikasi` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+
+UPDATE `LanggananStok` SET `created_at` = COALESCE(`updated_at`, CURRENT_TIMESTAMP) WHERE `created_at` IS NULL;
+UPDATE `LanggananStok` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
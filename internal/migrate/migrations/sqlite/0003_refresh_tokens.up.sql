@@ -0,0 +1,19 @@
+-- Refresh token yang dirotasi setiap kali dipakai. Hanya hash token yang
+-- disimpan; family mengelompokkan semua token dari satu login.
+
+CREATE TABLE `RefreshToken` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime NOT NULL,
+  `updated_at` datetime NOT NULL,
+  `deleted_at` datetime,
+  `id_user` integer NOT NULL,
+  `family` text NOT NULL,
+  `token_hash` text NOT NULL,
+  `expires_at` datetime NOT NULL,
+  `rotated_at` datetime,
+  `revoked_at` datetime
+);
+CREATE INDEX `idx_RefreshToken_deleted_at` ON `RefreshToken`(`deleted_at`);
+CREATE INDEX `idx_RefreshToken_id_user` ON `RefreshToken`(`id_user`);
+CREATE INDEX `idx_RefreshToken_family` ON `RefreshToken`(`family`);
+CREATE UNIQUE INDEX `idx_RefreshToken_token_hash` ON `RefreshToken`(`token_hash`);
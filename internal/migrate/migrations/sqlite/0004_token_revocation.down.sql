@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS `RevokedToken`;
+ALTER TABLE `Users` DROP COLUMN `banned_at`;
+ALTER TABLE `Users` DROP COLUMN `alasan_ban`;
+ALTER TABLE `Users` DROP COLUMN `token_version`;
@@ -0,0 +1,20 @@
+-- Pencabutan token: access token tunggal (logout) lewat jti, dan semua sesi
+-- user lewat Users.token_version (logout semua, ganti password, blokir).
+
+ALTER TABLE `Users` ADD COLUMN `banned_at` datetime;
+ALTER TABLE `Users` ADD COLUMN `alasan_ban` text DEFAULT null;
+ALTER TABLE `Users` ADD COLUMN `token_version` integer NOT NULL DEFAULT 0;
+
+CREATE TABLE `RevokedToken` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime NOT NULL,
+  `updated_at` datetime NOT NULL,
+  `deleted_at` datetime,
+  `jti` text NOT NULL,
+  `id_user` integer NOT NULL,
+  `expires_at` datetime NOT NULL
+);
+CREATE INDEX `idx_RevokedToken_deleted_at` ON `RevokedToken`(`deleted_at`);
+CREATE UNIQUE INDEX `idx_RevokedToken_jti` ON `RevokedToken`(`jti`);
+CREATE INDEX `idx_RevokedToken_id_user` ON `RevokedToken`(`id_user`);
+CREATE INDEX `idx_RevokedToken_expires_at` ON `RevokedToken`(`expires_at`);
@@ -0,0 +1,16 @@
+-- Token reset password. Hanya hash yang disimpan; used_at terisi setelah
+-- token dipakai atau dibatalkan oleh permintaan reset yang lebih baru.
+
+CREATE TABLE `PasswordReset` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime NOT NULL,
+  `updated_at` datetime NOT NULL,
+  `deleted_at` datetime,
+  `id_user` integer NOT NULL,
+  `token_hash` text NOT NULL,
+  `expires_at` datetime NOT NULL,
+  `used_at` datetime
+);
+CREATE INDEX `idx_PasswordReset_deleted_at` ON `PasswordReset`(`deleted_at`);
+CREATE INDEX `idx_PasswordReset_id_user` ON `PasswordReset`(`id_user`);
+CREATE UNIQUE INDEX `idx_PasswordReset_token_hash` ON `PasswordReset`(`token_hash`);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS `VerificationCode`;
+ALTER TABLE `Users` DROP COLUMN `email_verified_at`;
+ALTER TABLE `Users` DROP COLUMN `phone_verified_at`;
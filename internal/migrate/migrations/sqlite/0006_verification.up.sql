@@ -0,0 +1,23 @@
+-- Verifikasi email dan no telp. User yang sudah ada sebelum fitur ini
+-- dianggap terverifikasi supaya tidak tiba-tiba tidak bisa checkout.
+
+ALTER TABLE `Users` ADD COLUMN `email_verified_at` datetime;
+ALTER TABLE `Users` ADD COLUMN `phone_verified_at` datetime;
+
+UPDATE `Users` SET `email_verified_at` = `created_at`, `phone_verified_at` = `created_at`;
+
+CREATE TABLE `VerificationCode` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime NOT NULL,
+  `updated_at` datetime NOT NULL,
+  `deleted_at` datetime,
+  `id_user` integer NOT NULL,
+  `channel` text NOT NULL,
+  `target` text NOT NULL,
+  `code_hash` text NOT NULL,
+  `expires_at` datetime NOT NULL,
+  `attempts` integer NOT NULL DEFAULT 0,
+  `used_at` datetime
+);
+CREATE INDEX `idx_VerificationCode_deleted_at` ON `VerificationCode`(`deleted_at`);
+CREATE INDEX `idx_VerificationCode_user_channel` ON `VerificationCode`(`id_user`, `channel`);
@@ -0,0 +1,15 @@
+CREATE TABLE `LoginAttempt` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime NOT NULL,
+  `updated_at` datetime NOT NULL,
+  `deleted_at` datetime,
+  `email` text NOT NULL,
+  `id_user` integer,
+  `ip` text NOT NULL,
+  `user_agent` text NOT NULL,
+  `outcome` text NOT NULL
+);
+CREATE INDEX `idx_LoginAttempt_deleted_at` ON `LoginAttempt`(`deleted_at`);
+CREATE INDEX `idx_LoginAttempt_id_user` ON `LoginAttempt`(`id_user`);
+CREATE INDEX `idx_LoginAttempt_email_created` ON `LoginAttempt`(`email`, `created_at`);
+CREATE INDEX `idx_LoginAttempt_ip_created` ON `LoginAttempt`(`ip`, `created_at`);
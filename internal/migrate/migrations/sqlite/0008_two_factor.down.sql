@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS `RecoveryCode`;
+DROP TABLE IF EXISTS `LoginChallenge`;
+ALTER TABLE `Users` DROP COLUMN `totp_secret`;
+ALTER TABLE `Users` DROP COLUMN `totp_enabled_at`;
+ALTER TABLE `Users` DROP COLUMN `totp_last_step`;
@@ -0,0 +1,30 @@
+ALTER TABLE `Users` ADD COLUMN `totp_secret` text;
+ALTER TABLE `Users` ADD COLUMN `totp_enabled_at` datetime;
+ALTER TABLE `Users` ADD COLUMN `totp_last_step` integer NOT NULL DEFAULT 0;
+
+CREATE TABLE `LoginChallenge` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime NOT NULL,
+  `updated_at` datetime NOT NULL,
+  `deleted_at` datetime,
+  `id_user` integer NOT NULL,
+  `token_hash` text NOT NULL,
+  `expires_at` datetime NOT NULL,
+  `attempts` integer NOT NULL DEFAULT 0,
+  `used_at` datetime
+);
+CREATE INDEX `idx_LoginChallenge_deleted_at` ON `LoginChallenge`(`deleted_at`);
+CREATE INDEX `idx_LoginChallenge_id_user` ON `LoginChallenge`(`id_user`);
+CREATE UNIQUE INDEX `idx_LoginChallenge_token_hash` ON `LoginChallenge`(`token_hash`);
+
+CREATE TABLE `RecoveryCode` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime NOT NULL,
+  `updated_at` datetime NOT NULL,
+  `deleted_at` datetime,
+  `id_user` integer NOT NULL,
+  `code_hash` text NOT NULL,
+  `used_at` datetime
+);
+CREATE INDEX `idx_RecoveryCode_deleted_at` ON `RecoveryCode`(`deleted_at`);
+CREATE INDEX `idx_RecoveryCode_id_user` ON `RecoveryCode`(`id_user`);
@@ -0,0 +1,10 @@
+-- Samakan no telp lama ke format E.164 (+62...) seperti yang dipakai
+-- Register dan login. Nomor yang hasilnya bentrok dengan user lain
+-- dibiarkan apa adanya (OR IGNORE).
+
+UPDATE OR IGNORE `Users`
+SET `notelp` = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(`notelp`, ' ', ''), '-', ''), '.', ''), '(', ''), ')', '');
+
+UPDATE OR IGNORE `Users` SET `notelp` = '+62' || SUBSTR(`notelp`, 2) WHERE `notelp` LIKE '0%';
+UPDATE OR IGNORE `Users` SET `notelp` = '+' || `notelp` WHERE `notelp` LIKE '62%';
+UPDATE OR IGNORE `Users` SET `notelp` = '+62' || `notelp` WHERE `notelp` LIKE '8%';
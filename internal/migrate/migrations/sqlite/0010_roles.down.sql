@@ -0,0 +1,3 @@
+ALTER TABLE `Users` ADD COLUMN `is_admin` boolean DEFAULT false;
+UPDATE `Users` SET `is_admin` = 1 WHERE `id` IN (SELECT `id_user` FROM `UserRole` WHERE `role` = 'admin');
+DROP TABLE IF EXISTS `UserRole`;
@@ -0,0 +1,17 @@
+CREATE TABLE `UserRole` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime NOT NULL,
+  `updated_at` datetime NOT NULL,
+  `deleted_at` datetime,
+  `id_user` integer NOT NULL,
+  `role` text NOT NULL,
+  `id_pemberi` integer
+);
+CREATE INDEX `idx_UserRole_deleted_at` ON `UserRole`(`deleted_at`);
+CREATE UNIQUE INDEX `idx_UserRole_user_role` ON `UserRole`(`id_user`, `role`);
+
+-- flag is_admin diganti role admin
+INSERT INTO `UserRole` (`created_at`, `updated_at`, `id_user`, `role`)
+SELECT CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, `id`, 'admin' FROM `Users` WHERE `is_admin` = 1;
+
+ALTER TABLE `Users` DROP COLUMN `is_admin`;
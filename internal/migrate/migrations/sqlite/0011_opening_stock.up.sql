@@ -0,0 +1,8 @@
+-- Produk yang dibuat sebelum ada ledger stok tidak punya mutasi sama sekali,
+-- sehingga selalu terlihat selisih di /store/stock/reconcile. Catat stok
+-- saat ini sebagai saldo awal untuk setiap produk tersebut.
+
+INSERT INTO `MutasiStok` (`created_at`, `updated_at`, `id_produk`, `tipe`, `jumlah`, `stok_sebelum`, `stok_sesudah`, `catatan`)
+SELECT CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, `p`.`id`, 'adjustment', `p`.`stok`, 0, `p`.`stok`, 'Stok awal (migrasi)'
+FROM `Produk` `p`
+WHERE NOT EXISTS (SELECT 1 FROM `MutasiStok` `m` WHERE `m`.`id_produk` = `p`.`id`);
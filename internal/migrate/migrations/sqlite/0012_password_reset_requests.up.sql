@@ -0,0 +1,14 @@
+-- Setiap permintaan lupa password, untuk membatasi permintaan per email dan
+-- per IP tanpa membedakan email terdaftar atau tidak.
+
+CREATE TABLE `PasswordResetRequest` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime NOT NULL,
+  `updated_at` datetime NOT NULL,
+  `deleted_at` datetime,
+  `email` text NOT NULL,
+  `ip` text NOT NULL
+);
+CREATE INDEX `idx_PasswordResetRequest_deleted_at` ON `PasswordResetRequest`(`deleted_at`);
+CREATE INDEX `idx_PasswordResetRequest_email_created` ON `PasswordResetRequest`(`email`, `created_at`);
+CREATE INDEX `idx_PasswordResetRequest_ip_created` ON `PasswordResetRequest`(`ip`, `created_at`);
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"go-evermos/internal/entities"
+
+	"gorm.io/gorm"
+)
+
+type AddressFilter struct {
+	Judul    string
+	Penerima string
+	Telp     string
+	Page
+}
+
+type AddressRepository interface {
+	Create(address *entities.Address) error
+	FindForUser(id, userID uint) (*entities.Address, error)
+	ListForUser(userID uint, filter AddressFilter) ([]entities.Address, error)
+	Save(address *entities.Address) error
+	DeleteForUser(id, userID uint) error
+}
+
+type gormAddressRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormAddressRepository) Create(address *entities.Address) error {
+	return r.db.Create(address).Error
+}
+
+func (r *gormAddressRepository) FindForUser(id, userID uint) (*entities.Address, error) {
+	var address entities.Address
+	if err := r.db.Where("id = ? AND id_user = ?", id, userID).First(&address).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &address, nil
+}
+
+func (r *gormAddressRepository) ListForUser(userID uint, filter AddressFilter) ([]entities.Address, error) {
+	db := r.db
+
+	// Filtering
+	if filter.Judul != "" {
+		db = db.Where("judul_alamat LIKE ?", "%"+filter.Judul+"%")
+	}
+	if filter.Penerima != "" {
+		db = db.Where("nama_penerima LIKE ?", "%"+filter.Penerima+"%")
+	}
+	if filter.Telp != "" {
+		db = db.Where("no_telp LIKE ?", "%"+filter.Telp+"%")
+	}
+
+	var addresses []entities.Address
+	err := db.Where("id_user = ?", userID).
+		Offset(filter.Offset()).
+		Limit(filter.Normalize().Limit).
+		Find(&addresses).Error
+	return addresses, err
+}
+
+func (r *gormAddressRepository) Save(address *entities.Address) error {
+	return r.db.Save(address).Error
+}
+
+func (r *gormAddressRepository) DeleteForUser(id, userID uint) error {
+	return r.db.Where("id = ? AND id_user = ?", id, userID).Delete(&entities.Address{}).Error
+}
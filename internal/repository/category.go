@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"go-evermos/internal/entities"
+
+	"gorm.io/gorm"
+)
+
+type CategoryFilter struct {
+	Nama string
+	Page
+}
+
+type CategoryRepository interface {
+	Create(category *entities.Category) error
+	FindByID(id uint) (*entities.Category, error)
+	List(filter CategoryFilter) ([]entities.Category, error)
+	All() ([]entities.Category, error)
+	Save(category *entities.Category) error
+	Delete(id uint) error
+}
+
+type gormCategoryRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormCategoryRepository) Create(category *entities.Category) error {
+	return r.db.Create(category).Error
+}
+
+func (r *gormCategoryRepository) FindByID(id uint) (*entities.Category, error) {
+	var category entities.Category
+	if err := r.db.First(&category, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &category, nil
+}
+
+func (r *gormCategoryRepository) List(filter CategoryFilter) ([]entities.Category, error) {
+	db := r.db
+
+	// Filtering by nama_category
+	if filter.Nama != "" {
+		db = db.Where("nama_category LIKE ?", "%"+filter.Nama+"%")
+	}
+
+	var categories []entities.Category
+	err := db.Offset(filter.Offset()).Limit(filter.Normalize().Limit).Find(&categories).Error
+	return categories, err
+}
+
+func (r *gormCategoryRepository) All() ([]entities.Category, error) {
+	var categories []entities.Category
+	err := r.db.Find(&categories).Error
+	return categories, err
+}
+
+func (r *gormCategoryRepository) Save(category *entities.Category) error {
+	return r.db.Save(category).Error
+}
+
+func (r *gormCategoryRepository) Delete(id uint) error {
+	return r.db.Delete(&entities.Category{}, id).Error
+}
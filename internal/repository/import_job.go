@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"go-evermos/internal/entities"
+
+	"gorm.io/gorm"
+)
+
+type ImportJobRepository interface {
+	Create(job *entities.ImportJob) error
+	FindForStore(id, storeID uint) (*entities.ImportJob, error)
+	Save(job *entities.ImportJob) error
+}
+
+type gormImportJobRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormImportJobRepository) Create(job *entities.ImportJob) error {
+	return r.db.Create(job).Error
+}
+
+func (r *gormImportJobRepository) FindForStore(id, storeID uint) (*entities.ImportJob, error) {
+	var job entities.ImportJob
+	if err := r.db.Where("id = ? AND id_toko = ?", id, storeID).First(&job).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &job, nil
+}
+
+func (r *gormImportJobRepository) Save(job *entities.ImportJob) error {
+	return r.db.Save(job).Error
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"go-evermos/internal/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type LoginAttemptFilter struct {
+	Email   string
+	IP      string
+	Outcome string
+	Page
+}
+
+// LoginFailures adalah jumlah login gagal dan waktu gagal terakhir
+type LoginFailures struct {
+	Count int64
+	Last  time.Time
+}
+
+type LoginAttemptRepository interface {
+	Create(attempt *entities.LoginAttempt) error
+	// EmailFailures menghitung login gagal untuk email sejak waktu tertentu,
+	// tetapi hanya yang terjadi setelah login sukses terakhir
+	EmailFailures(email string, since time.Time) (LoginFailures, error)
+	// IPFailures menghitung login gagal dari IP sejak waktu tertentu
+	IPFailures(ip string, since time.Time) (LoginFailures, error)
+	List(filter LoginAttemptFilter) ([]entities.LoginAttempt, int64, error)
+}
+
+type gormLoginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormLoginAttemptRepository) Create(attempt *entities.LoginAttempt) error {
+	return r.db.Create(attempt).Error
+}
+
+func (r *gormLoginAttemptRepository) EmailFailures(email string, since time.Time) (LoginFailures, error) {
+	var last []time.Time
+	err := r.db.Model(&entities.LoginAttempt{}).
+		Where("email = ? AND outcome = ? AND created_at >= ?", email, entities.LoginSuccess, since).
+		Order("created_at DESC").Limit(1).
+		Pluck("created_at", &last).Error
+	if err != nil {
+		return LoginFailures{}, err
+	}
+	if len(last) > 0 {
+		since = last[0]
+	}
+	return r.failures(r.db.Where("email = ? AND created_at > ?", email, since))
+}
+
+func (r *gormLoginAttemptRepository) IPFailures(ip string, since time.Time) (LoginFailures, error) {
+	return r.failures(r.db.Where("ip = ? AND created_at >= ?", ip, since))
+}
+
+func (r *gormLoginAttemptRepository) failures(db *gorm.DB) (LoginFailures, error) {
+	db = db.Model(&entities.LoginAttempt{}).Where("outcome = ?", entities.LoginFailed)
+
+	var result LoginFailures
+	if err := db.Count(&result.Count).Error; err != nil || result.Count == 0 {
+		return result, err
+	}
+
+	var last []time.Time
+	if err := db.Order("created_at DESC").Limit(1).Pluck("created_at", &last).Error; err != nil {
+		return result, err
+	}
+	result.Last = last[0]
+	return result, nil
+}
+
+func (r *gormLoginAttemptRepository) List(filter LoginAttemptFilter) ([]entities.LoginAttempt, int64, error) {
+	db := r.db.Model(&entities.LoginAttempt{})
+	if filter.Email != "" {
+		db = db.Where("email = ?", filter.Email)
+	}
+	if filter.IP != "" {
+		db = db.Where("ip = ?", filter.IP)
+	}
+	if filter.Outcome != "" {
+		db = db.Where("outcome = ?", filter.Outcome)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var attempts []entities.LoginAttempt
+	err := db.Order("id DESC").Offset(filter.Offset()).Limit(filter.Normalize().Limit).Find(&attempts).Error
+	return attempts, total, err
+}
@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+)
+
+type addressRepository struct {
+	d *db
+}
+
+func (r *addressRepository) Create(address *entities.Address) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	r.d.insert(&address.Model, "addresses")
+	r.d.addresses[address.ID] = *address
+	return nil
+}
+
+func (r *addressRepository) FindForUser(id, userID uint) (*entities.Address, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	address, ok := r.d.addresses[id]
+	if !ok || address.IDUser != userID {
+		return nil, repository.ErrNotFound
+	}
+	return &address, nil
+}
+
+func (r *addressRepository) ListForUser(userID uint, filter repository.AddressFilter) ([]entities.Address, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	var result []entities.Address
+	for _, a := range sortedByID(r.d.addresses) {
+		if a.IDUser != userID ||
+			!like(a.JudulAlamat, filter.Judul) ||
+			!like(a.NamaPenerima, filter.Penerima) ||
+			!like(a.NoTelp, filter.Telp) {
+			continue
+		}
+		result = append(result, a)
+	}
+	return paginate(result, filter.Page), nil
+}
+
+func (r *addressRepository) Save(address *entities.Address) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	touch(&address.Model)
+	r.d.addresses[address.ID] = *address
+	return nil
+}
+
+func (r *addressRepository) DeleteForUser(id, userID uint) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	if address, ok := r.d.addresses[id]; ok && address.IDUser == userID {
+		delete(r.d.addresses, id)
+	}
+	return nil
+}
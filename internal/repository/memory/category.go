@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+)
+
+type categoryRepository struct {
+	d *db
+}
+
+func (r *categoryRepository) Create(category *entities.Category) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	r.d.insert(&category.Model, "categories")
+	r.d.categories[category.ID] = *category
+	return nil
+}
+
+func (r *categoryRepository) FindByID(id uint) (*entities.Category, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	category, ok := r.d.categories[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &category, nil
+}
+
+func (r *categoryRepository) List(filter repository.CategoryFilter) ([]entities.Category, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	var result []entities.Category
+	for _, cat := range sortedByID(r.d.categories) {
+		if like(cat.NamaCategory, filter.Nama) {
+			result = append(result, cat)
+		}
+	}
+	return paginate(result, filter.Page), nil
+}
+
+func (r *categoryRepository) All() ([]entities.Category, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	return sortedByID(r.d.categories), nil
+}
+
+func (r *categoryRepository) Save(category *entities.Category) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	touch(&category.Model)
+	r.d.categories[category.ID] = *category
+	return nil
+}
+
+func (r *categoryRepository) Delete(id uint) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	delete(r.d.categories, id)
+	return nil
+}
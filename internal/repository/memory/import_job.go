@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+)
+
+type importJobRepository struct {
+	d *db
+}
+
+func (r *importJobRepository) Create(job *entities.ImportJob) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	r.d.insert(&job.Model, "importJobs")
+	r.d.importJobs[job.ID] = *job
+	return nil
+}
+
+func (r *importJobRepository) FindForStore(id, storeID uint) (*entities.ImportJob, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	job, ok := r.d.importJobs[id]
+	if !ok || job.IDToko != storeID {
+		return nil, repository.ErrNotFound
+	}
+	return &job, nil
+}
+
+func (r *importJobRepository) Save(job *entities.ImportJob) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	touch(&job.Model)
+	r.d.importJobs[job.ID] = *job
+	return nil
+}
@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"slices"
+	"time"
+)
+
+type loginAttemptRepository struct {
+	d *db
+}
+
+func (r *loginAttemptRepository) Create(attempt *entities.LoginAttempt) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	r.d.insert(&attempt.Model, "loginAttempts")
+	r.d.loginAttempts[attempt.ID] = *attempt
+	return nil
+}
+
+func (r *loginAttemptRepository) EmailFailures(email string, since time.Time) (repository.LoginFailures, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	after := since
+	for _, a := range r.d.loginAttempts {
+		if a.Email == email && a.Outcome == entities.LoginSuccess && !a.CreatedAt.Before(after) {
+			after = a.CreatedAt
+		}
+	}
+	return r.failures(func(a entities.LoginAttempt) bool {
+		return a.Email == email && a.CreatedAt.After(after)
+	}), nil
+}
+
+func (r *loginAttemptRepository) IPFailures(ip string, since time.Time) (repository.LoginFailures, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	return r.failures(func(a entities.LoginAttempt) bool {
+		return a.IP == ip && !a.CreatedAt.Before(since)
+	}), nil
+}
+
+func (r *loginAttemptRepository) failures(match func(entities.LoginAttempt) bool) repository.LoginFailures {
+	var result repository.LoginFailures
+	for _, a := range r.d.loginAttempts {
+		if a.Outcome == entities.LoginFailed && match(a) {
+			result.Count++
+			if a.CreatedAt.After(result.Last) {
+				result.Last = a.CreatedAt
+			}
+		}
+	}
+	return result
+}
+
+func (r *loginAttemptRepository) List(filter repository.LoginAttemptFilter) ([]entities.LoginAttempt, int64, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	var result []entities.LoginAttempt
+	for _, a := range r.d.loginAttempts {
+		if (filter.Email == "" || a.Email == filter.Email) &&
+			(filter.IP == "" || a.IP == filter.IP) &&
+			(filter.Outcome == "" || a.Outcome == filter.Outcome) {
+			result = append(result, a)
+		}
+	}
+	slices.SortFunc(result, compareDesc(func(a entities.LoginAttempt) uint { return a.ID }))
+	return paginate(result, filter.Page), int64(len(result)), nil
+}
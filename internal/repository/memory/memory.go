@@ -0,0 +1,219 @@
+// Package memory berisi implementasi repository di memori untuk test
+// service dan handler tanpa database.
+package memory
+
+import (
+	"cmp"
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// db menyimpan semua tabel. Satu mutex untuk semua tabel supaya query
+// yang menggabungkan beberapa tabel tetap konsisten.
+type db struct {
+	mu   sync.Mutex
+	txMu sync.Mutex
+	// seq adalah auto increment per tabel
+	seq map[string]uint
+
+	users             map[uint]entities.User
+	stores            map[uint]entities.Store
+	addresses         map[uint]entities.Address
+	categories        map[uint]entities.Category
+	products          map[uint]entities.Product
+	pictures          map[uint]entities.ProductPicture
+	movements         map[uint]entities.StockMovement
+	subscriptions     map[uint]entities.StockSubscription
+	productLogs       map[uint]entities.ProductLog
+	trxs              map[uint]entities.Trx
+	details           map[uint]entities.TrxDetail
+	notifications     map[uint]entities.Notification
+	importJobs        map[uint]entities.ImportJob
+	refreshTokens     map[uint]entities.RefreshToken
+	revokedTokens     map[uint]entities.RevokedToken
+	passwordResets    map[uint]entities.PasswordReset
+	resetRequests     map[uint]entities.PasswordResetRequest
+	verificationCodes map[uint]entities.VerificationCode
+	loginAttempts     map[uint]entities.LoginAttempt
+	loginChallenges   map[uint]entities.LoginChallenge
+	recoveryCodes     map[uint]entities.RecoveryCode
+	userRoles         map[uint]entities.UserRole
+}
+
+func newDB() *db {
+	return &db{
+		seq:               map[string]uint{},
+		users:             map[uint]entities.User{},
+		stores:            map[uint]entities.Store{},
+		addresses:         map[uint]entities.Address{},
+		categories:        map[uint]entities.Category{},
+		products:          map[uint]entities.Product{},
+		pictures:          map[uint]entities.ProductPicture{},
+		movements:         map[uint]entities.StockMovement{},
+		subscriptions:     map[uint]entities.StockSubscription{},
+		productLogs:       map[uint]entities.ProductLog{},
+		trxs:              map[uint]entities.Trx{},
+		details:           map[uint]entities.TrxDetail{},
+		notifications:     map[uint]entities.Notification{},
+		importJobs:        map[uint]entities.ImportJob{},
+		refreshTokens:     map[uint]entities.RefreshToken{},
+		revokedTokens:     map[uint]entities.RevokedToken{},
+		passwordResets:    map[uint]entities.PasswordReset{},
+		resetRequests:     map[uint]entities.PasswordResetRequest{},
+		verificationCodes: map[uint]entities.VerificationCode{},
+		loginAttempts:     map[uint]entities.LoginAttempt{},
+		loginChallenges:   map[uint]entities.LoginChallenge{},
+		recoveryCodes:     map[uint]entities.RecoveryCode{},
+		userRoles:         map[uint]entities.UserRole{},
+	}
+}
+
+// clone menyalin semua tabel, dipakai untuk rollback transaksi
+func (d *db) clone() *db {
+	return &db{
+		seq:               maps.Clone(d.seq),
+		users:             maps.Clone(d.users),
+		stores:            maps.Clone(d.stores),
+		addresses:         maps.Clone(d.addresses),
+		categories:        maps.Clone(d.categories),
+		products:          maps.Clone(d.products),
+		pictures:          maps.Clone(d.pictures),
+		movements:         maps.Clone(d.movements),
+		subscriptions:     maps.Clone(d.subscriptions),
+		productLogs:       maps.Clone(d.productLogs),
+		trxs:              maps.Clone(d.trxs),
+		details:           maps.Clone(d.details),
+		notifications:     maps.Clone(d.notifications),
+		importJobs:        maps.Clone(d.importJobs),
+		refreshTokens:     maps.Clone(d.refreshTokens),
+		revokedTokens:     maps.Clone(d.revokedTokens),
+		passwordResets:    maps.Clone(d.passwordResets),
+		resetRequests:     maps.Clone(d.resetRequests),
+		verificationCodes: maps.Clone(d.verificationCodes),
+		loginAttempts:     maps.Clone(d.loginAttempts),
+		loginChallenges:   maps.Clone(d.loginChallenges),
+		recoveryCodes:     maps.Clone(d.recoveryCodes),
+		userRoles:         maps.Clone(d.userRoles),
+	}
+}
+
+// restore mengembalikan isi tabel dari snapshot
+func (d *db) restore(s *db) {
+	d.seq = s.seq
+	d.users = s.users
+	d.stores = s.stores
+	d.addresses = s.addresses
+	d.categories = s.categories
+	d.products = s.products
+	d.pictures = s.pictures
+	d.movements = s.movements
+	d.subscriptions = s.subscriptions
+	d.productLogs = s.productLogs
+	d.trxs = s.trxs
+	d.details = s.details
+	d.notifications = s.notifications
+	d.importJobs = s.importJobs
+	d.refreshTokens = s.refreshTokens
+	d.revokedTokens = s.revokedTokens
+	d.passwordResets = s.passwordResets
+	d.resetRequests = s.resetRequests
+	d.verificationCodes = s.verificationCodes
+	d.loginAttempts = s.loginAttempts
+	d.loginChallenges = s.loginChallenges
+	d.recoveryCodes = s.recoveryCodes
+	d.userRoles = s.userRoles
+}
+
+// insert memberi ID auto increment dan mengisi waktu dibuat/diubah,
+// sama seperti yang dilakukan GORM saat Create
+func (d *db) insert(m *entities.Model, table string) {
+	d.seq[table]++
+	m.ID = d.seq[table]
+
+	now := time.Now()
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = now
+	}
+	m.UpdatedAt = now
+}
+
+// touch memperbarui UpdatedAt seperti GORM saat Save/Updates
+func touch(m *entities.Model) {
+	m.UpdatedAt = time.Now()
+}
+
+// New membuat Repositories di memori. Transaksi dijalankan berurutan dan
+// di-rollback (dari snapshot) jika fn mengembalikan error.
+func New() *repository.Repositories {
+	d := newDB()
+	r := &repository.Repositories{
+		Users:           &userRepository{d},
+		Stores:          &storeRepository{d},
+		Addresses:       &addressRepository{d},
+		Categories:      &categoryRepository{d},
+		Products:        &productRepository{d},
+		Transactions:    &transactionRepository{d},
+		Notifications:   &notificationRepository{d},
+		ImportJobs:      &importJobRepository{d},
+		RefreshTokens:   &refreshTokenRepository{d},
+		RevokedTokens:   &revokedTokenRepository{d},
+		PasswordResets:  &passwordResetRepository{d},
+		Verifications:   &verificationCodeRepository{d},
+		LoginAttempts:   &loginAttemptRepository{d},
+		LoginChallenges: &loginChallengeRepository{d},
+		RecoveryCodes:   &recoveryCodeRepository{d},
+		UserRoles:       &userRoleRepository{d},
+	}
+	return r.WithTransaction(func(fn func(tx *repository.Repositories) error) error {
+		d.txMu.Lock()
+		defer d.txMu.Unlock()
+
+		d.mu.Lock()
+		snapshot := d.clone()
+		d.mu.Unlock()
+
+		if err := fn(r); err != nil {
+			d.mu.Lock()
+			d.restore(snapshot)
+			d.mu.Unlock()
+			return err
+		}
+		return nil
+	})
+}
+
+// sortedByID mengembalikan isi map terurut berdasarkan ID
+func sortedByID[V any](m map[uint]V) []V {
+	keys := slices.Sorted(maps.Keys(m))
+	result := make([]V, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, m[k])
+	}
+	return result
+}
+
+// paginate memotong hasil sesuai page dan limit
+func paginate[V any](items []V, page repository.Page) []V {
+	offset := page.Offset()
+	if offset >= len(items) {
+		return []V{}
+	}
+	end := min(offset+page.Normalize().Limit, len(items))
+	return items[offset:end]
+}
+
+// like meniru LIKE '%s%' MySQL (case-insensitive)
+func like(value, pattern string) bool {
+	return strings.Contains(strings.ToLower(value), strings.ToLower(pattern))
+}
+
+func compareDesc[V any](id func(V) uint) func(a, b V) int {
+	return func(a, b V) int {
+		return cmp.Compare(id(b), id(a))
+	}
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"slices"
+	"time"
+)
+
+type notificationRepository struct {
+	d *db
+}
+
+func (r *notificationRepository) Create(notifs ...*entities.Notification) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for _, n := range notifs {
+		r.d.insert(&n.Model, "notifications")
+		r.d.notifications[n.ID] = *n
+	}
+	return nil
+}
+
+func (r *notificationRepository) ListForUser(userID uint, filter repository.NotificationFilter) ([]entities.Notification, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	var result []entities.Notification
+	for _, n := range sortedByID(r.d.notifications) {
+		if n.IDUser != userID ||
+			(filter.Unread && n.DibacaAt != nil) ||
+			(filter.Tipe != "" && n.Tipe != filter.Tipe) {
+			continue
+		}
+		result = append(result, n)
+	}
+	slices.SortFunc(result, compareDesc(func(n entities.Notification) uint { return n.ID }))
+	return paginate(result, filter.Page), nil
+}
+
+func (r *notificationRepository) MarkRead(id, userID uint, at time.Time) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	n, ok := r.d.notifications[id]
+	if ok && n.IDUser == userID && n.DibacaAt == nil {
+		n.DibacaAt = &at
+		touch(&n.Model)
+		r.d.notifications[id] = n
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"time"
+)
+
+type passwordResetRepository struct {
+	d *db
+}
+
+func (r *passwordResetRepository) Create(reset *entities.PasswordReset) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for _, p := range r.d.passwordResets {
+		if p.TokenHash == reset.TokenHash {
+			return errDuplicate
+		}
+	}
+	r.d.insert(&reset.Model, "passwordResets")
+	r.d.passwordResets[reset.ID] = *reset
+	return nil
+}
+
+func (r *passwordResetRepository) FindByHashForUpdate(hash string) (*entities.PasswordReset, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for _, p := range r.d.passwordResets {
+		if p.TokenHash == hash {
+			return &p, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *passwordResetRepository) InvalidateUser(userID uint, at time.Time) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for id, p := range r.d.passwordResets {
+		if p.IDUser == userID && p.UsedAt == nil {
+			p.UsedAt = &at
+			touch(&p.Model)
+			r.d.passwordResets[id] = p
+		}
+	}
+	return nil
+}
+
+func (r *passwordResetRepository) CreateRequest(req *entities.PasswordResetRequest) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	r.d.insert(&req.Model, "resetRequests")
+	r.d.resetRequests[req.ID] = *req
+	return nil
+}
+
+func (r *passwordResetRepository) EmailRequests(email string, since time.Time) (repository.ResetRequests, error) {
+	return r.requests(func(req entities.PasswordResetRequest) bool {
+		return req.Email == email && !req.CreatedAt.Before(since)
+	}), nil
+}
+
+func (r *passwordResetRepository) IPRequests(ip string, since time.Time) (repository.ResetRequests, error) {
+	return r.requests(func(req entities.PasswordResetRequest) bool {
+		return req.IP == ip && !req.CreatedAt.Before(since)
+	}), nil
+}
+
+func (r *passwordResetRepository) requests(match func(entities.PasswordResetRequest) bool) repository.ResetRequests {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	var result repository.ResetRequests
+	for _, req := range r.d.resetRequests {
+		if match(req) {
+			result.Count++
+			if result.First.IsZero() || req.CreatedAt.Before(result.First) {
+				result.First = req.CreatedAt
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,310 @@
+package memory
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"slices"
+	"strconv"
+)
+
+type productRepository struct {
+	d *db
+}
+
+func (r *productRepository) Create(produk *entities.Product) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	r.d.insert(&produk.Model, "products")
+	if produk.Status == "" {
+		produk.Status = entities.ProductStatusActive
+	}
+	r.d.products[produk.ID] = *produk
+	return nil
+}
+
+func (r *productRepository) CreatePicture(foto *entities.ProductPicture) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	r.d.insert(&foto.Model, "pictures")
+	r.d.pictures[foto.ID] = *foto
+	return nil
+}
+
+func (r *productRepository) FindByID(id uint) (*entities.Product, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	produk, ok := r.d.products[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &produk, nil
+}
+
+func (r *productRepository) FindByIDForUpdate(id uint) (*entities.Product, error) {
+	return r.FindByID(id)
+}
+
+func (r *productRepository) FindDetail(id uint) (*entities.Product, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	produk, ok := r.d.products[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	r.preload(&produk)
+	return &produk, nil
+}
+
+func (r *productRepository) FindOwned(id, userID uint) (*entities.Product, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	produk, ok := r.d.products[id]
+	if !ok || r.d.stores[produk.IDToko].IDUser != userID {
+		return nil, repository.ErrNotFound
+	}
+	return &produk, nil
+}
+
+// preload mengisi relasi foto, kategori dan toko. Harus dipanggil dengan mu terkunci.
+func (r *productRepository) preload(produk *entities.Product) {
+	produk.ProductPicture = nil
+	for _, foto := range sortedByID(r.d.pictures) {
+		if foto.IDProduk == produk.ID {
+			produk.ProductPicture = append(produk.ProductPicture, foto)
+		}
+	}
+	produk.Category = r.d.categories[produk.IDCategory]
+	produk.Store = r.d.stores[produk.IDToko]
+}
+
+func (r *productRepository) List(filter repository.ProductFilter) ([]entities.Product, int64, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	var result []entities.Product
+	for _, p := range sortedByID(r.d.products) {
+		if filter.Status != "" && p.Status != filter.Status {
+			continue
+		}
+		if filter.IDToko != 0 && p.IDToko != filter.IDToko {
+			continue
+		}
+		if !like(p.NamaProduk, filter.Nama) {
+			continue
+		}
+		if filter.Category != "" && strconv.FormatUint(uint64(p.IDCategory), 10) != filter.Category {
+			continue
+		}
+		if filter.Toko != "" && strconv.FormatUint(uint64(p.IDToko), 10) != filter.Toko {
+			continue
+		}
+		if filter.MinPrice != "" && comparePrice(p.HargaKonsumen, filter.MinPrice) < 0 {
+			continue
+		}
+		if filter.MaxPrice != "" && comparePrice(p.HargaKonsumen, filter.MaxPrice) > 0 {
+			continue
+		}
+		r.preload(&p)
+		result = append(result, p)
+	}
+	return paginate(result, filter.Page), int64(len(result)), nil
+}
+
+// comparePrice membandingkan harga sebagai angka jika bisa, selain itu sebagai string
+func comparePrice(a, b string) int {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	if errA != nil || errB != nil {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	}
+	return x - y
+}
+
+func (r *productRepository) ListByStore(storeID uint) ([]entities.Product, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	var result []entities.Product
+	for _, p := range sortedByID(r.d.products) {
+		if p.IDToko == storeID {
+			r.preload(&p)
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+func (r *productRepository) UpdateDetails(produk *entities.Product) error {
+	return r.update(produk.ID, func(p *entities.Product) {
+		p.NamaProduk = produk.NamaProduk
+		p.Slug = produk.Slug
+		p.HargaReseller = produk.HargaReseller
+		p.HargaKonsumen = produk.HargaKonsumen
+		p.StokMinimum = produk.StokMinimum
+		p.Deskripsi = produk.Deskripsi
+		p.IDCategory = produk.IDCategory
+	})
+}
+
+func (r *productRepository) update(id uint, fn func(p *entities.Product)) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	produk, ok := r.d.products[id]
+	if !ok {
+		return nil
+	}
+	fn(&produk)
+	touch(&produk.Model)
+	r.d.products[id] = produk
+	return nil
+}
+
+func (r *productRepository) UpdateStock(id uint, stok, stokDipesan int) error {
+	return r.update(id, func(p *entities.Product) {
+		p.Stok = stok
+		p.StokDipesan = stokDipesan
+	})
+}
+
+func (r *productRepository) ReleaseReserved(id uint, qty int) error {
+	return r.update(id, func(p *entities.Product) {
+		p.StokDipesan = max(p.StokDipesan-qty, 0)
+	})
+}
+
+func (r *productRepository) UpdateStatus(id uint, status string, alasanBan *string) error {
+	return r.update(id, func(p *entities.Product) {
+		p.Status = status
+		p.AlasanBan = alasanBan
+	})
+}
+
+func (r *productRepository) Delete(produk *entities.Product) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	delete(r.d.products, produk.ID)
+	return nil
+}
+
+func (r *productRepository) CreateStockMovement(movement *entities.StockMovement) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	r.d.insert(&movement.Model, "movements")
+	r.d.movements[movement.ID] = *movement
+	return nil
+}
+
+func (r *productRepository) ListStockMovements(productID uint, filter repository.StockMovementFilter) ([]entities.StockMovement, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	var result []entities.StockMovement
+	for _, m := range sortedByID(r.d.movements) {
+		if m.IDProduk == productID && (filter.Tipe == "" || m.Tipe == filter.Tipe) {
+			result = append(result, m)
+		}
+	}
+	slices.SortFunc(result, compareDesc(func(m entities.StockMovement) uint { return m.ID }))
+	return paginate(result, filter.Page), nil
+}
+
+func (r *productRepository) LedgerStock(productID uint) (int, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	return r.ledgerStock(productID), nil
+}
+
+func (r *productRepository) ledgerStock(productID uint) int {
+	total := 0
+	for _, m := range r.d.movements {
+		if m.IDProduk == productID {
+			total += m.Jumlah
+		}
+	}
+	return total
+}
+
+func (r *productRepository) ReconcileStore(storeID uint) ([]repository.StockReconcileRow, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	var rows []repository.StockReconcileRow
+	for _, p := range sortedByID(r.d.products) {
+		if p.IDToko != storeID {
+			continue
+		}
+		rows = append(rows, repository.StockReconcileRow{
+			IDProduk:   p.ID,
+			NamaProduk: p.NamaProduk,
+			Stok:       p.Stok,
+			StokLedger: r.ledgerStock(p.ID),
+		})
+	}
+	return rows, nil
+}
+
+func (r *productRepository) Subscribe(sub *entities.StockSubscription) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for _, s := range r.d.subscriptions {
+		if s.IDProduk == sub.IDProduk && s.IDUser == sub.IDUser {
+			return nil
+		}
+	}
+	r.d.insert(&sub.Model, "subscriptions")
+	r.d.subscriptions[sub.ID] = *sub
+	return nil
+}
+
+func (r *productRepository) Unsubscribe(productID, userID uint) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for id, s := range r.d.subscriptions {
+		if s.IDProduk == productID && s.IDUser == userID {
+			delete(r.d.subscriptions, id)
+		}
+	}
+	return nil
+}
+
+func (r *productRepository) ListSubscribers(productID uint) ([]entities.StockSubscription, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	var result []entities.StockSubscription
+	for _, s := range sortedByID(r.d.subscriptions) {
+		if s.IDProduk == productID {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func (r *productRepository) ClearSubscribers(productID uint) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for id, s := range r.d.subscriptions {
+		if s.IDProduk == productID {
+			delete(r.d.subscriptions, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"time"
+)
+
+type refreshTokenRepository struct {
+	d *db
+}
+
+func (r *refreshTokenRepository) Create(token *entities.RefreshToken) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for _, t := range r.d.refreshTokens {
+		if t.TokenHash == token.TokenHash {
+			return errDuplicate
+		}
+	}
+	r.d.insert(&token.Model, "refreshTokens")
+	r.d.refreshTokens[token.ID] = *token
+	return nil
+}
+
+func (r *refreshTokenRepository) FindByHashForUpdate(hash string) (*entities.RefreshToken, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for _, t := range r.d.refreshTokens {
+		if t.TokenHash == hash {
+			return &t, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *refreshTokenRepository) MarkRotated(id uint, at time.Time) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	if t, ok := r.d.refreshTokens[id]; ok {
+		t.RotatedAt = &at
+		touch(&t.Model)
+		r.d.refreshTokens[id] = t
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(family string, at time.Time) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for id, t := range r.d.refreshTokens {
+		if t.Family == family && t.RevokedAt == nil {
+			t.RevokedAt = &at
+			touch(&t.Model)
+			r.d.refreshTokens[id] = t
+		}
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) RevokeUser(userID uint, at time.Time) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for id, t := range r.d.refreshTokens {
+		if t.IDUser == userID && t.RevokedAt == nil {
+			t.RevokedAt = &at
+			touch(&t.Model)
+			r.d.refreshTokens[id] = t
+		}
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) FamilyRevoked(family string) (bool, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for _, t := range r.d.refreshTokens {
+		if t.Family == family && t.RevokedAt != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
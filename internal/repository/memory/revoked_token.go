@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"go-evermos/internal/entities"
+	"time"
+)
+
+type revokedTokenRepository struct {
+	d *db
+}
+
+func (r *revokedTokenRepository) Create(token *entities.RevokedToken) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for _, t := range r.d.revokedTokens {
+		if t.JTI == token.JTI {
+			return nil
+		}
+	}
+	r.d.insert(&token.Model, "revokedTokens")
+	r.d.revokedTokens[token.ID] = *token
+	return nil
+}
+
+func (r *revokedTokenRepository) Exists(jti string) (bool, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for _, t := range r.d.revokedTokens {
+		if t.JTI == jti {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *revokedTokenRepository) DeleteExpired(now time.Time) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for id, t := range r.d.revokedTokens {
+		if t.ExpiresAt.Before(now) {
+			delete(r.d.revokedTokens, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"errors"
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+)
+
+// errDuplicate meniru pelanggaran unique index
+var errDuplicate = errors.New("duplicate entry")
+
+type storeRepository struct {
+	d *db
+}
+
+func (r *storeRepository) Create(store *entities.Store) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	r.d.insert(&store.Model, "stores")
+	r.d.stores[store.ID] = *store
+	return nil
+}
+
+func (r *storeRepository) FindByID(id uint) (*entities.Store, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	store, ok := r.d.stores[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &store, nil
+}
+
+func (r *storeRepository) FindByUserID(userID uint) (*entities.Store, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for _, store := range sortedByID(r.d.stores) {
+		if store.IDUser == userID {
+			return &store, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *storeRepository) Save(store *entities.Store) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	touch(&store.Model)
+	r.d.stores[store.ID] = *store
+	return nil
+}
@@ -0,0 +1,145 @@
+package memory
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"time"
+)
+
+type transactionRepository struct {
+	d *db
+}
+
+func (r *transactionRepository) CreateProductLog(prodLog *entities.ProductLog) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	r.d.insert(&prodLog.Model, "productLogs")
+	r.d.productLogs[prodLog.ID] = *prodLog
+	return nil
+}
+
+func (r *transactionRepository) Create(trx *entities.Trx) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	r.d.insert(&trx.Model, "trxs")
+	saved := *trx
+	saved.TrxDetail = nil
+	r.d.trxs[trx.ID] = saved
+	return nil
+}
+
+func (r *transactionRepository) CreateDetail(detail *entities.TrxDetail) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	r.d.insert(&detail.Model, "details")
+	r.d.details[detail.ID] = *detail
+	return nil
+}
+
+// withDetails mengisi relasi TrxDetail. Harus dipanggil dengan mu terkunci.
+func (r *transactionRepository) withDetails(trx entities.Trx) entities.Trx {
+	trx.TrxDetail = nil
+	for _, d := range sortedByID(r.d.details) {
+		if d.IDTrx == trx.ID {
+			trx.TrxDetail = append(trx.TrxDetail, d)
+		}
+	}
+	return trx
+}
+
+func (r *transactionRepository) FindForUser(id, userID uint) (*entities.Trx, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	trx, ok := r.d.trxs[id]
+	if !ok || trx.IDUser != userID {
+		return nil, repository.ErrNotFound
+	}
+	trx = r.withDetails(trx)
+	return &trx, nil
+}
+
+func (r *transactionRepository) FindForUpdate(id uint) (*entities.Trx, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	trx, ok := r.d.trxs[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &trx, nil
+}
+
+func (r *transactionRepository) ListForUser(userID uint, filter repository.TrxFilter) ([]entities.Trx, error) {
+	filter.IDUser = userID
+	return r.List(filter)
+}
+
+func (r *transactionRepository) List(filter repository.TrxFilter) ([]entities.Trx, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	var result []entities.Trx
+	for _, trx := range sortedByID(r.d.trxs) {
+		if (filter.IDUser != 0 && trx.IDUser != filter.IDUser) ||
+			(filter.Method != "" && trx.MethodBayar != filter.Method) ||
+			(filter.Status != "" && trx.StatusBayar != filter.Status) ||
+			!like(trx.KodeInvoice, filter.Invoice) {
+			continue
+		}
+		result = append(result, r.withDetails(trx))
+	}
+	return paginate(result, filter.Page), nil
+}
+
+func (r *transactionRepository) ListExpiredPending(now time.Time) ([]uint, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	var ids []uint
+	for _, trx := range sortedByID(r.d.trxs) {
+		if trx.StatusBayar == entities.TrxStatusPending && trx.BatasBayar != nil && trx.BatasBayar.Before(now) {
+			ids = append(ids, trx.ID)
+		}
+	}
+	return ids, nil
+}
+
+func (r *transactionRepository) ReservedItems(trxID uint) ([]repository.ReservedItem, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	var items []repository.ReservedItem
+	index := map[uint]int{}
+	for _, d := range sortedByID(r.d.details) {
+		if d.IDTrx != trxID {
+			continue
+		}
+		idProduk := r.d.productLogs[d.IDLogProduk].IDProduk
+		if i, ok := index[idProduk]; ok {
+			items[i].Kuantitas += d.Kuantitas
+			continue
+		}
+		index[idProduk] = len(items)
+		items = append(items, repository.ReservedItem{IDProduk: idProduk, Kuantitas: d.Kuantitas})
+	}
+	return items, nil
+}
+
+func (r *transactionRepository) UpdatePayment(trx *entities.Trx) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	saved, ok := r.d.trxs[trx.ID]
+	if !ok {
+		return nil
+	}
+	saved.StatusBayar = trx.StatusBayar
+	saved.TanggalBayar = trx.TanggalBayar
+	touch(&saved.Model)
+	r.d.trxs[trx.ID] = saved
+	return nil
+}
@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"time"
+)
+
+type loginChallengeRepository struct {
+	d *db
+}
+
+func (r *loginChallengeRepository) Create(challenge *entities.LoginChallenge) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for _, c := range r.d.loginChallenges {
+		if c.TokenHash == challenge.TokenHash {
+			return errDuplicate
+		}
+	}
+	r.d.insert(&challenge.Model, "loginChallenges")
+	r.d.loginChallenges[challenge.ID] = *challenge
+	return nil
+}
+
+func (r *loginChallengeRepository) FindByHashForUpdate(hash string) (*entities.LoginChallenge, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for _, c := range r.d.loginChallenges {
+		if c.TokenHash == hash {
+			return &c, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *loginChallengeRepository) Save(challenge *entities.LoginChallenge) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	touch(&challenge.Model)
+	r.d.loginChallenges[challenge.ID] = *challenge
+	return nil
+}
+
+type recoveryCodeRepository struct {
+	d *db
+}
+
+func (r *recoveryCodeRepository) Create(codes []entities.RecoveryCode) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for i := range codes {
+		r.d.insert(&codes[i].Model, "recoveryCodes")
+		r.d.recoveryCodes[codes[i].ID] = codes[i]
+	}
+	return nil
+}
+
+func (r *recoveryCodeRepository) DeleteUser(userID uint) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for id, c := range r.d.recoveryCodes {
+		if c.IDUser == userID {
+			delete(r.d.recoveryCodes, id)
+		}
+	}
+	return nil
+}
+
+func (r *recoveryCodeRepository) FindUnusedForUpdate(userID uint, hash string) (*entities.RecoveryCode, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for _, c := range r.d.recoveryCodes {
+		if c.IDUser == userID && c.CodeHash == hash && c.UsedAt == nil {
+			return &c, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *recoveryCodeRepository) MarkUsed(id uint, at time.Time) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	if c, ok := r.d.recoveryCodes[id]; ok {
+		c.UsedAt = &at
+		touch(&c.Model)
+		r.d.recoveryCodes[id] = c
+	}
+	return nil
+}
+
+func (r *recoveryCodeRepository) CountUnused(userID uint) (int64, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	var count int64
+	for _, c := range r.d.recoveryCodes {
+		if c.IDUser == userID && c.UsedAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+)
+
+type userRepository struct {
+	d *db
+}
+
+func (r *userRepository) Create(user *entities.User) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for _, u := range r.d.users {
+		if u.Email == user.Email || u.Notelp == user.Notelp {
+			return errDuplicate
+		}
+	}
+	r.d.insert(&user.Model, "users")
+	r.d.users[user.ID] = *user
+	return nil
+}
+
+func (r *userRepository) FindByID(id uint) (*entities.User, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	user, ok := r.d.users[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByEmail(email string) (*entities.User, error) {
+	return r.findBy(func(u entities.User) bool { return u.Email == email })
+}
+
+func (r *userRepository) FindByPhone(notelp string) (*entities.User, error) {
+	return r.findBy(func(u entities.User) bool { return u.Notelp == notelp })
+}
+
+func (r *userRepository) findBy(match func(entities.User) bool) (*entities.User, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for _, u := range sortedByID(r.d.users) {
+		if match(u) {
+			return &u, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *userRepository) Save(user *entities.User) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for _, u := range r.d.users {
+		if u.ID != user.ID && (u.Email == user.Email || u.Notelp == user.Notelp) {
+			return errDuplicate
+		}
+	}
+	touch(&user.Model)
+	r.d.users[user.ID] = *user
+	return nil
+}
+
+func (r *userRepository) AdvanceTOTPStep(userID uint, step int64) (bool, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	user, ok := r.d.users[userID]
+	if !ok || user.TOTPLastStep >= step {
+		return false, nil
+	}
+	user.TOTPLastStep = step
+	touch(&user.Model)
+	r.d.users[userID] = user
+	return true, nil
+}
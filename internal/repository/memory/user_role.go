@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"go-evermos/internal/entities"
+)
+
+type userRoleRepository struct {
+	d *db
+}
+
+func (r *userRoleRepository) ListByUser(userID uint) ([]entities.UserRole, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	var result []entities.UserRole
+	for _, ur := range sortedByID(r.d.userRoles) {
+		if ur.IDUser == userID {
+			result = append(result, ur)
+		}
+	}
+	return result, nil
+}
+
+func (r *userRoleRepository) Create(role *entities.UserRole) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for _, ur := range r.d.userRoles {
+		if ur.IDUser == role.IDUser && ur.Role == role.Role {
+			return errDuplicate
+		}
+	}
+	r.d.insert(&role.Model, "userRoles")
+	r.d.userRoles[role.ID] = *role
+	return nil
+}
+
+func (r *userRoleRepository) Delete(userID uint, role string) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	for id, ur := range r.d.userRoles {
+		if ur.IDUser == userID && ur.Role == role {
+			delete(r.d.userRoles, id)
+		}
+	}
+	return nil
+}
+
+func (r *userRoleRepository) CountUsers(role string) (int64, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	var count int64
+	for _, ur := range r.d.userRoles {
+		user, ok := r.d.users[ur.IDUser]
+		if ur.Role == role && ok && user.BannedAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
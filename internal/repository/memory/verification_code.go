@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"time"
+)
+
+type verificationCodeRepository struct {
+	d *db
+}
+
+func (r *verificationCodeRepository) Create(code *entities.VerificationCode) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	r.d.insert(&code.Model, "verificationCodes")
+	r.d.verificationCodes[code.ID] = *code
+	return nil
+}
+
+func (r *verificationCodeRepository) FindLatestForUpdate(userID uint, channel string) (*entities.VerificationCode, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	var latest *entities.VerificationCode
+	for _, c := range r.d.verificationCodes {
+		if c.IDUser == userID && c.Channel == channel && (latest == nil || c.ID > latest.ID) {
+			latest = &c
+		}
+	}
+	if latest == nil {
+		return nil, repository.ErrNotFound
+	}
+	return latest, nil
+}
+
+func (r *verificationCodeRepository) CountSince(userID uint, channel string, since time.Time) (int64, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	var count int64
+	for _, c := range r.d.verificationCodes {
+		if c.IDUser == userID && c.Channel == channel && !c.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *verificationCodeRepository) Save(code *entities.VerificationCode) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+
+	touch(&code.Model)
+	r.d.verificationCodes[code.ID] = *code
+	return nil
+}
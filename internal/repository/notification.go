@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"go-evermos/internal/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type NotificationFilter struct {
+	Unread bool
+	Tipe   string
+	Page
+}
+
+type NotificationRepository interface {
+	Create(notifs ...*entities.Notification) error
+	ListForUser(userID uint, filter NotificationFilter) ([]entities.Notification, error)
+	MarkRead(id, userID uint, at time.Time) error
+}
+
+type gormNotificationRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormNotificationRepository) Create(notifs ...*entities.Notification) error {
+	if len(notifs) == 0 {
+		return nil
+	}
+	return r.db.Create(notifs).Error
+}
+
+func (r *gormNotificationRepository) ListForUser(userID uint, filter NotificationFilter) ([]entities.Notification, error) {
+	db := r.db.Where("id_user = ?", userID)
+
+	// Filtering
+	if filter.Unread {
+		db = db.Where("dibaca_at IS NULL")
+	}
+	if filter.Tipe != "" {
+		db = db.Where("tipe = ?", filter.Tipe)
+	}
+
+	var notifs []entities.Notification
+	err := db.Order("id DESC").Offset(filter.Offset()).Limit(filter.Normalize().Limit).Find(&notifs).Error
+	return notifs, err
+}
+
+func (r *gormNotificationRepository) MarkRead(id, userID uint, at time.Time) error {
+	return r.db.Model(&entities.Notification{}).
+		Where("id = ? AND id_user = ? AND dibaca_at IS NULL", id, userID).
+		Update("dibaca_at", at).Error
+}
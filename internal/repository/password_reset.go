@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"go-evermos/internal/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ResetRequests adalah jumlah permintaan reset sejak waktu tertentu dan
+// waktu permintaan paling awal di rentang tersebut
+type ResetRequests struct {
+	Count int64
+	First time.Time
+}
+
+type PasswordResetRepository interface {
+	Create(reset *entities.PasswordReset) error
+	// FindByHashForUpdate mengunci baris token supaya token yang sama tidak
+	// bisa dipakai dua kali oleh request bersamaan
+	FindByHashForUpdate(hash string) (*entities.PasswordReset, error)
+	// InvalidateUser menandai semua token user yang belum dipakai sebagai
+	// terpakai
+	InvalidateUser(userID uint, at time.Time) error
+
+	CreateRequest(req *entities.PasswordResetRequest) error
+	// EmailRequests dan IPRequests menghitung permintaan reset untuk email
+	// atau dari IP sejak waktu tertentu
+	EmailRequests(email string, since time.Time) (ResetRequests, error)
+	IPRequests(ip string, since time.Time) (ResetRequests, error)
+}
+
+type gormPasswordResetRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormPasswordResetRepository) Create(reset *entities.PasswordReset) error {
+	return r.db.Create(reset).Error
+}
+
+func (r *gormPasswordResetRepository) FindByHashForUpdate(hash string) (*entities.PasswordReset, error) {
+	var reset entities.PasswordReset
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", hash).
+		First(&reset).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &reset, nil
+}
+
+func (r *gormPasswordResetRepository) InvalidateUser(userID uint, at time.Time) error {
+	return r.db.Model(&entities.PasswordReset{}).
+		Where("id_user = ? AND used_at IS NULL", userID).
+		Update("used_at", at).Error
+}
+
+func (r *gormPasswordResetRepository) CreateRequest(req *entities.PasswordResetRequest) error {
+	return r.db.Create(req).Error
+}
+
+func (r *gormPasswordResetRepository) EmailRequests(email string, since time.Time) (ResetRequests, error) {
+	return r.requests(r.db.Where("email = ? AND created_at >= ?", email, since))
+}
+
+func (r *gormPasswordResetRepository) IPRequests(ip string, since time.Time) (ResetRequests, error) {
+	return r.requests(r.db.Where("ip = ? AND created_at >= ?", ip, since))
+}
+
+func (r *gormPasswordResetRepository) requests(db *gorm.DB) (ResetRequests, error) {
+	db = db.Model(&entities.PasswordResetRequest{})
+
+	var result ResetRequests
+	if err := db.Count(&result.Count).Error; err != nil || result.Count == 0 {
+		return result, err
+	}
+
+	var first []time.Time
+	if err := db.Order("created_at ASC").Limit(1).Pluck("created_at", &first).Error; err != nil {
+		return result, err
+	}
+	result.First = first[0]
+	return result, nil
+}
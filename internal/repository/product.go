@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"go-evermos/internal/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProductFilter struct {
+	Nama     string
+	Category string
+	MinPrice string
+	MaxPrice string
+	Toko     string
+	// Status kosong berarti semua status
+	Status string
+	// IDToko membatasi ke produk satu toko (0 = semua toko)
+	IDToko uint
+	Page
+}
+
+type StockMovementFilter struct {
+	Tipe string
+	Page
+}
+
+// StockReconcileRow membandingkan stok produk dengan total mutasi di ledger
+type StockReconcileRow struct {
+	IDProduk   uint   `json:"id_produk"`
+	NamaProduk string `json:"nama_produk"`
+	Stok       int    `json:"stok"`
+	StokLedger int    `json:"stok_ledger"`
+	Selisih    int    `json:"selisih"`
+}
+
+type ProductRepository interface {
+	Create(produk *entities.Product) error
+	CreatePicture(foto *entities.ProductPicture) error
+	FindByID(id uint) (*entities.Product, error)
+	// FindByIDForUpdate mengunci baris produk sampai transaksi selesai
+	FindByIDForUpdate(id uint) (*entities.Product, error)
+	// FindDetail mengambil produk beserta foto, kategori dan toko
+	FindDetail(id uint) (*entities.Product, error)
+	// FindOwned mengambil produk yang tokonya milik userID
+	FindOwned(id, userID uint) (*entities.Product, error)
+	List(filter ProductFilter) ([]entities.Product, int64, error)
+	ListByStore(storeID uint) ([]entities.Product, error)
+	// UpdateDetails hanya menulis kolom yang bisa diedit pemilik toko, tanpa
+	// stok dan status yang diubah lewat UpdateStock dan UpdateStatus
+	UpdateDetails(produk *entities.Product) error
+	UpdateStock(id uint, stok, stokDipesan int) error
+	// ReleaseReserved mengurangi StokDipesan (minimal 0) saat reservasi jadi penjualan
+	ReleaseReserved(id uint, qty int) error
+	UpdateStatus(id uint, status string, alasanBan *string) error
+	Delete(produk *entities.Product) error
+
+	CreateStockMovement(movement *entities.StockMovement) error
+	ListStockMovements(productID uint, filter StockMovementFilter) ([]entities.StockMovement, error)
+	LedgerStock(productID uint) (int, error)
+	ReconcileStore(storeID uint) ([]StockReconcileRow, error)
+
+	// Subscribe idempoten: langganan ganda diabaikan
+	Subscribe(sub *entities.StockSubscription) error
+	Unsubscribe(productID, userID uint) error
+	ListSubscribers(productID uint) ([]entities.StockSubscription, error)
+	ClearSubscribers(productID uint) error
+}
+
+type gormProductRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormProductRepository) Create(produk *entities.Product) error {
+	return r.db.Create(produk).Error
+}
+
+func (r *gormProductRepository) CreatePicture(foto *entities.ProductPicture) error {
+	return r.db.Create(foto).Error
+}
+
+func (r *gormProductRepository) FindByID(id uint) (*entities.Product, error) {
+	var produk entities.Product
+	if err := r.db.First(&produk, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &produk, nil
+}
+
+func (r *gormProductRepository) FindByIDForUpdate(id uint) (*entities.Product, error) {
+	var produk entities.Product
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&produk, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &produk, nil
+}
+
+func (r *gormProductRepository) FindDetail(id uint) (*entities.Product, error) {
+	var produk entities.Product
+	if err := r.db.Preload("ProductPicture").
+		Preload("Category").
+		Preload("Store").
+		First(&produk, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &produk, nil
+}
+
+func (r *gormProductRepository) FindOwned(id, userID uint) (*entities.Product, error) {
+	var produk entities.Product
+	if err := r.db.Joins("JOIN Toko ON Toko.id = Produk.id_toko").
+		Where("Produk.id = ? AND Toko.id_user = ?", id, userID).
+		First(&produk).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &produk, nil
+}
+
+func (r *gormProductRepository) List(filter ProductFilter) ([]entities.Product, int64, error) {
+	db := r.db.Model(&entities.Product{})
+
+	// Filtering
+	if filter.Status != "" {
+		db = db.Where("status = ?", filter.Status)
+	}
+	if filter.IDToko != 0 {
+		db = db.Where("id_toko = ?", filter.IDToko)
+	}
+	if filter.Nama != "" {
+		db = db.Where("nama_produk LIKE ?", "%"+filter.Nama+"%")
+	}
+	if filter.Category != "" {
+		db = db.Where("id_category = ?", filter.Category)
+	}
+	if filter.MinPrice != "" {
+		db = db.Where("harga_konsumen >= ?", filter.MinPrice)
+	}
+	if filter.MaxPrice != "" {
+		db = db.Where("harga_konsumen <= ?", filter.MaxPrice)
+	}
+	if filter.Toko != "" {
+		db = db.Where("id_toko = ?", filter.Toko)
+	}
+
+	// Hitung total data (setelah filter)
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var products []entities.Product
+	err := db.Preload("ProductPicture").Preload("Category").Preload("Store").
+		Offset(filter.Offset()).Limit(filter.Normalize().Limit).Find(&products).Error
+	return products, total, err
+}
+
+func (r *gormProductRepository) ListByStore(storeID uint) ([]entities.Product, error) {
+	var products []entities.Product
+	err := r.db.Preload("ProductPicture").
+		Where("id_toko = ?", storeID).
+		Order("id").
+		Find(&products).Error
+	return products, err
+}
+
+func (r *gormProductRepository) UpdateDetails(produk *entities.Product) error {
+	return r.db.Model(&entities.Product{}).Where("id = ?", produk.ID).Updates(map[string]interface{}{
+		"nama_produk":    produk.NamaProduk,
+		"slug":           produk.Slug,
+		"harga_reseller": produk.HargaReseller,
+		"harga_konsumen": produk.HargaKonsumen,
+		"stok_minimum":   produk.StokMinimum,
+		"deskripsi":      produk.Deskripsi,
+		"id_category":    produk.IDCategory,
+	}).Error
+}
+
+func (r *gormProductRepository) UpdateStock(id uint, stok, stokDipesan int) error {
+	return r.db.Model(&entities.Product{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"stok":         stok,
+		"stok_dipesan": stokDipesan,
+	}).Error
+}
+
+func (r *gormProductRepository) ReleaseReserved(id uint, qty int) error {
+	return r.db.Model(&entities.Product{}).
+		Where("id = ?", id).
+		Update("stok_dipesan", gorm.Expr("CASE WHEN stok_dipesan > ? THEN stok_dipesan - ? ELSE 0 END", qty, qty)).Error
+}
+
+func (r *gormProductRepository) UpdateStatus(id uint, status string, alasanBan *string) error {
+	return r.db.Model(&entities.Product{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     status,
+		"alasan_ban": alasanBan,
+	}).Error
+}
+
+func (r *gormProductRepository) Delete(produk *entities.Product) error {
+	return r.db.Delete(produk).Error
+}
+
+func (r *gormProductRepository) CreateStockMovement(movement *entities.StockMovement) error {
+	return r.db.Create(movement).Error
+}
+
+func (r *gormProductRepository) ListStockMovements(productID uint, filter StockMovementFilter) ([]entities.StockMovement, error) {
+	db := r.db.Where("id_produk = ?", productID)
+	if filter.Tipe != "" {
+		db = db.Where("tipe = ?", filter.Tipe)
+	}
+
+	var movements []entities.StockMovement
+	err := db.Order("id DESC").Offset(filter.Offset()).Limit(filter.Normalize().Limit).Find(&movements).Error
+	return movements, err
+}
+
+func (r *gormProductRepository) LedgerStock(productID uint) (int, error) {
+	var total int
+	err := r.db.Model(&entities.StockMovement{}).
+		Where("id_produk = ?", productID).
+		Select("COALESCE(SUM(jumlah), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+func (r *gormProductRepository) ReconcileStore(storeID uint) ([]StockReconcileRow, error) {
+	var rows []StockReconcileRow
+	err := r.db.Table("Produk").
+		Select("Produk.id AS id_produk, Produk.nama_produk, Produk.stok, COALESCE(SUM(MutasiStok.jumlah), 0) AS stok_ledger").
+		Joins("LEFT JOIN MutasiStok ON MutasiStok.id_produk = Produk.id AND MutasiStok.deleted_at IS NULL").
+		Where("Produk.id_toko = ? AND Produk.deleted_at IS NULL", storeID).
+		Group("Produk.id, Produk.nama_produk, Produk.stok").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *gormProductRepository) Subscribe(sub *entities.StockSubscription) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(sub).Error
+}
+
+func (r *gormProductRepository) Unsubscribe(productID, userID uint) error {
+	return r.db.Unscoped().
+		Where("id_produk = ? AND id_user = ?", productID, userID).
+		Delete(&entities.StockSubscription{}).Error
+}
+
+func (r *gormProductRepository) ListSubscribers(productID uint) ([]entities.StockSubscription, error) {
+	var subs []entities.StockSubscription
+	err := r.db.Where("id_produk = ?", productID).Find(&subs).Error
+	return subs, err
+}
+
+func (r *gormProductRepository) ClearSubscribers(productID uint) error {
+	return r.db.Unscoped().Where("id_produk = ?", productID).Delete(&entities.StockSubscription{}).Error
+}
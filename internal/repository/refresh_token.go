@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"go-evermos/internal/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RefreshTokenRepository interface {
+	Create(token *entities.RefreshToken) error
+	// FindByHashForUpdate mengunci baris token supaya dua refresh bersamaan
+	// dengan token yang sama diproses bergantian
+	FindByHashForUpdate(hash string) (*entities.RefreshToken, error)
+	MarkRotated(id uint, at time.Time) error
+	// RevokeFamily mencabut semua token dalam satu sesi yang belum dicabut
+	RevokeFamily(family string, at time.Time) error
+	// RevokeUser mencabut semua sesi milik user
+	RevokeUser(userID uint, at time.Time) error
+	// FamilyRevoked bernilai true jika sesi sudah dicabut
+	FamilyRevoked(family string) (bool, error)
+}
+
+type gormRefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormRefreshTokenRepository) Create(token *entities.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *gormRefreshTokenRepository) FindByHashForUpdate(hash string) (*entities.RefreshToken, error) {
+	var token entities.RefreshToken
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", hash).
+		First(&token).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &token, nil
+}
+
+func (r *gormRefreshTokenRepository) MarkRotated(id uint, at time.Time) error {
+	return r.db.Model(&entities.RefreshToken{}).Where("id = ?", id).Update("rotated_at", at).Error
+}
+
+func (r *gormRefreshTokenRepository) RevokeFamily(family string, at time.Time) error {
+	return r.db.Model(&entities.RefreshToken{}).
+		Where("family = ? AND revoked_at IS NULL", family).
+		Update("revoked_at", at).Error
+}
+
+func (r *gormRefreshTokenRepository) RevokeUser(userID uint, at time.Time) error {
+	return r.db.Model(&entities.RefreshToken{}).
+		Where("id_user = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", at).Error
+}
+
+func (r *gormRefreshTokenRepository) FamilyRevoked(family string) (bool, error) {
+	var count int64
+	err := r.db.Model(&entities.RefreshToken{}).
+		Where("family = ? AND revoked_at IS NOT NULL", family).
+		Count(&count).Error
+	return count > 0, err
+}
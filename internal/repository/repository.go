@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotFound dikembalikan semua repository jika data tidak ditemukan
+var ErrNotFound = errors.New("data tidak ditemukan")
+
+// Repositories mengumpulkan repository semua aggregate. Service menerima
+// struct ini lewat constructor, sehingga implementasinya bisa diganti
+// (GORM untuk production, memory untuk test).
+type Repositories struct {
+	Users           UserRepository
+	Stores          StoreRepository
+	Addresses       AddressRepository
+	Categories      CategoryRepository
+	Products        ProductRepository
+	Transactions    TransactionRepository
+	Notifications   NotificationRepository
+	ImportJobs      ImportJobRepository
+	RefreshTokens   RefreshTokenRepository
+	RevokedTokens   RevokedTokenRepository
+	PasswordResets  PasswordResetRepository
+	Verifications   VerificationCodeRepository
+	LoginAttempts   LoginAttemptRepository
+	LoginChallenges LoginChallengeRepository
+	RecoveryCodes   RecoveryCodeRepository
+	UserRoles       UserRoleRepository
+
+	transaction func(fn func(tx *Repositories) error) error
+}
+
+// Transaction menjalankan fn dalam satu transaksi database. Semua akses data
+// di dalam fn harus lewat tx, bukan lewat Repositories asal.
+func (r *Repositories) Transaction(fn func(tx *Repositories) error) error {
+	if r.transaction == nil {
+		return fn(r)
+	}
+	return r.transaction(fn)
+}
+
+// WithTransaction mengatur cara Repositories menjalankan transaksi.
+// Dipakai oleh implementasi selain GORM.
+func (r *Repositories) WithTransaction(fn func(fn func(tx *Repositories) error) error) *Repositories {
+	r.transaction = fn
+	return r
+}
+
+// NewGorm membuat Repositories yang memakai database GORM
+func NewGorm(db *gorm.DB) *Repositories {
+	r := &Repositories{
+		Users:           &gormUserRepository{db},
+		Stores:          &gormStoreRepository{db},
+		Addresses:       &gormAddressRepository{db},
+		Categories:      &gormCategoryRepository{db},
+		Products:        &gormProductRepository{db},
+		Transactions:    &gormTransactionRepository{db},
+		Notifications:   &gormNotificationRepository{db},
+		ImportJobs:      &gormImportJobRepository{db},
+		RefreshTokens:   &gormRefreshTokenRepository{db},
+		RevokedTokens:   &gormRevokedTokenRepository{db},
+		PasswordResets:  &gormPasswordResetRepository{db},
+		Verifications:   &gormVerificationCodeRepository{db},
+		LoginAttempts:   &gormLoginAttemptRepository{db},
+		LoginChallenges: &gormLoginChallengeRepository{db},
+		RecoveryCodes:   &gormRecoveryCodeRepository{db},
+		UserRoles:       &gormUserRoleRepository{db},
+	}
+	r.transaction = func(fn func(tx *Repositories) error) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			return fn(NewGorm(tx))
+		})
+	}
+	return r
+}
+
+// Page adalah parameter pagination
+type Page struct {
+	Page  int
+	Limit int
+}
+
+// Normalize mengisi default page=1 dan limit=10
+func (p Page) Normalize() Page {
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	if p.Limit < 1 {
+		p.Limit = 10
+	}
+	return p
+}
+
+func (p Page) Offset() int {
+	p = p.Normalize()
+	return (p.Page - 1) * p.Limit
+}
+
+// notFound mengubah gorm.ErrRecordNotFound menjadi ErrNotFound
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"go-evermos/internal/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RevokedTokenRepository interface {
+	// Create mengabaikan jti yang sudah pernah dicabut
+	Create(token *entities.RevokedToken) error
+	Exists(jti string) (bool, error)
+	// DeleteExpired menghapus catatan token yang sudah kedaluwarsa
+	DeleteExpired(now time.Time) error
+}
+
+type gormRevokedTokenRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormRevokedTokenRepository) Create(token *entities.RevokedToken) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error
+}
+
+func (r *gormRevokedTokenRepository) Exists(jti string) (bool, error) {
+	var count int64
+	err := r.db.Model(&entities.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *gormRevokedTokenRepository) DeleteExpired(now time.Time) error {
+	return r.db.Unscoped().Where("expires_at < ?", now).Delete(&entities.RevokedToken{}).Error
+}
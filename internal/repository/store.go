@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"go-evermos/internal/entities"
+
+	"gorm.io/gorm"
+)
+
+type StoreRepository interface {
+	Create(store *entities.Store) error
+	FindByID(id uint) (*entities.Store, error)
+	FindByUserID(userID uint) (*entities.Store, error)
+	Save(store *entities.Store) error
+}
+
+type gormStoreRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormStoreRepository) Create(store *entities.Store) error {
+	return r.db.Create(store).Error
+}
+
+func (r *gormStoreRepository) FindByID(id uint) (*entities.Store, error) {
+	var store entities.Store
+	if err := r.db.First(&store, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &store, nil
+}
+
+func (r *gormStoreRepository) FindByUserID(userID uint) (*entities.Store, error) {
+	var store entities.Store
+	if err := r.db.Where("id_user = ?", userID).First(&store).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &store, nil
+}
+
+func (r *gormStoreRepository) Save(store *entities.Store) error {
+	return r.db.Save(store).Error
+}
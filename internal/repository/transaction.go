@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"go-evermos/internal/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TrxFilter struct {
+	// IDUser membatasi ke transaksi satu user, 0 berarti semua user
+	IDUser  uint
+	Method  string
+	Invoice string
+	Status  string
+	Page
+}
+
+// ReservedItem adalah jumlah stok yang dipesan per produk dalam satu transaksi
+type ReservedItem struct {
+	IDProduk  uint
+	Kuantitas int
+}
+
+type TransactionRepository interface {
+	CreateProductLog(prodLog *entities.ProductLog) error
+	Create(trx *entities.Trx) error
+	CreateDetail(detail *entities.TrxDetail) error
+	// FindForUser mengambil transaksi milik user beserta detailnya
+	FindForUser(id, userID uint) (*entities.Trx, error)
+	// FindForUpdate mengunci baris transaksi sampai transaksi DB selesai
+	FindForUpdate(id uint) (*entities.Trx, error)
+	ListForUser(userID uint, filter TrxFilter) ([]entities.Trx, error)
+	// List mengambil transaksi semua user, dipakai route admin
+	List(filter TrxFilter) ([]entities.Trx, error)
+	// ListExpiredPending mengambil ID transaksi pending yang lewat batas bayar
+	ListExpiredPending(now time.Time) ([]uint, error)
+	ReservedItems(trxID uint) ([]ReservedItem, error)
+	// UpdatePayment menyimpan StatusBayar dan TanggalBayar
+	UpdatePayment(trx *entities.Trx) error
+}
+
+type gormTransactionRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormTransactionRepository) CreateProductLog(prodLog *entities.ProductLog) error {
+	return r.db.Create(prodLog).Error
+}
+
+func (r *gormTransactionRepository) Create(trx *entities.Trx) error {
+	return r.db.Create(trx).Error
+}
+
+func (r *gormTransactionRepository) CreateDetail(detail *entities.TrxDetail) error {
+	return r.db.Create(detail).Error
+}
+
+func (r *gormTransactionRepository) FindForUser(id, userID uint) (*entities.Trx, error) {
+	var trx entities.Trx
+	if err := r.db.Preload("TrxDetail").
+		Where("id = ? AND id_user = ?", id, userID).
+		First(&trx).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &trx, nil
+}
+
+func (r *gormTransactionRepository) FindForUpdate(id uint) (*entities.Trx, error) {
+	var trx entities.Trx
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&trx).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &trx, nil
+}
+
+func (r *gormTransactionRepository) ListForUser(userID uint, filter TrxFilter) ([]entities.Trx, error) {
+	filter.IDUser = userID
+	return r.List(filter)
+}
+
+func (r *gormTransactionRepository) List(filter TrxFilter) ([]entities.Trx, error) {
+	db := r.db
+
+	// Filtering
+	if filter.IDUser != 0 {
+		db = db.Where("id_user = ?", filter.IDUser)
+	}
+	if filter.Method != "" {
+		db = db.Where("method_bayar = ?", filter.Method)
+	}
+	if filter.Invoice != "" {
+		db = db.Where("kode_invoice LIKE ?", "%"+filter.Invoice+"%")
+	}
+	if filter.Status != "" {
+		db = db.Where("status_bayar = ?", filter.Status)
+	}
+
+	var trxs []entities.Trx
+	err := db.Preload("TrxDetail").
+		Offset(filter.Offset()).Limit(filter.Normalize().Limit).
+		Find(&trxs).Error
+	return trxs, err
+}
+
+func (r *gormTransactionRepository) ListExpiredPending(now time.Time) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&entities.Trx{}).
+		Where("status_bayar = ? AND batas_bayar < ?", entities.TrxStatusPending, now).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *gormTransactionRepository) ReservedItems(trxID uint) ([]ReservedItem, error) {
+	var items []ReservedItem
+	err := r.db.Table("TrxDetail").
+		Select("ProdukLog.id_produk AS id_produk, SUM(TrxDetail.kuantitas) AS kuantitas").
+		Joins("JOIN ProdukLog ON ProdukLog.id = TrxDetail.id_log_produk").
+		Where("TrxDetail.id_trx = ? AND TrxDetail.deleted_at IS NULL", trxID).
+		Group("ProdukLog.id_produk").
+		Scan(&items).Error
+	return items, err
+}
+
+func (r *gormTransactionRepository) UpdatePayment(trx *entities.Trx) error {
+	return r.db.Model(&entities.Trx{}).Where("id = ?", trx.ID).Updates(map[string]interface{}{
+		"status_bayar":  trx.StatusBayar,
+		"tanggal_bayar": trx.TanggalBayar,
+	}).Error
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"go-evermos/internal/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LoginChallengeRepository interface {
+	Create(challenge *entities.LoginChallenge) error
+	// FindByHashForUpdate mengunci challenge supaya tidak bisa ditukar dua
+	// kali oleh request bersamaan
+	FindByHashForUpdate(hash string) (*entities.LoginChallenge, error)
+	Save(challenge *entities.LoginChallenge) error
+}
+
+type RecoveryCodeRepository interface {
+	Create(codes []entities.RecoveryCode) error
+	// DeleteUser menghapus semua kode cadangan user
+	DeleteUser(userID uint) error
+	// FindUnusedForUpdate mencari kode cadangan user yang belum dipakai
+	FindUnusedForUpdate(userID uint, hash string) (*entities.RecoveryCode, error)
+	MarkUsed(id uint, at time.Time) error
+	CountUnused(userID uint) (int64, error)
+}
+
+type gormLoginChallengeRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormLoginChallengeRepository) Create(challenge *entities.LoginChallenge) error {
+	return r.db.Create(challenge).Error
+}
+
+func (r *gormLoginChallengeRepository) FindByHashForUpdate(hash string) (*entities.LoginChallenge, error) {
+	var challenge entities.LoginChallenge
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", hash).
+		First(&challenge).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &challenge, nil
+}
+
+func (r *gormLoginChallengeRepository) Save(challenge *entities.LoginChallenge) error {
+	return r.db.Save(challenge).Error
+}
+
+type gormRecoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormRecoveryCodeRepository) Create(codes []entities.RecoveryCode) error {
+	return r.db.Create(&codes).Error
+}
+
+func (r *gormRecoveryCodeRepository) DeleteUser(userID uint) error {
+	return r.db.Unscoped().Where("id_user = ?", userID).Delete(&entities.RecoveryCode{}).Error
+}
+
+func (r *gormRecoveryCodeRepository) FindUnusedForUpdate(userID uint, hash string) (*entities.RecoveryCode, error) {
+	var code entities.RecoveryCode
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id_user = ? AND code_hash = ? AND used_at IS NULL", userID, hash).
+		First(&code).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &code, nil
+}
+
+func (r *gormRecoveryCodeRepository) MarkUsed(id uint, at time.Time) error {
+	return r.db.Model(&entities.RecoveryCode{}).Where("id = ?", id).Update("used_at", at).Error
+}
+
+func (r *gormRecoveryCodeRepository) CountUnused(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&entities.RecoveryCode{}).Where("id_user = ? AND used_at IS NULL", userID).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"go-evermos/internal/entities"
+
+	"gorm.io/gorm"
+)
+
+type UserRepository interface {
+	Create(user *entities.User) error
+	FindByID(id uint) (*entities.User, error)
+	FindByEmail(email string) (*entities.User, error)
+	FindByPhone(notelp string) (*entities.User, error)
+	Save(user *entities.User) error
+	// AdvanceTOTPStep menyimpan periode TOTP terakhir yang dipakai hanya
+	// jika lebih baru dari yang tersimpan. false berarti kode sudah pernah
+	// dipakai (termasuk oleh request bersamaan).
+	AdvanceTOTPStep(userID uint, step int64) (bool, error)
+}
+
+type gormUserRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormUserRepository) Create(user *entities.User) error {
+	return r.db.Create(user).Error
+}
+
+func (r *gormUserRepository) FindByID(id uint) (*entities.User, error) {
+	var user entities.User
+	if err := r.db.First(&user, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &user, nil
+}
+
+func (r *gormUserRepository) FindByEmail(email string) (*entities.User, error) {
+	var user entities.User
+	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &user, nil
+}
+
+func (r *gormUserRepository) FindByPhone(notelp string) (*entities.User, error) {
+	var user entities.User
+	if err := r.db.Where("notelp = ?", notelp).First(&user).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &user, nil
+}
+
+func (r *gormUserRepository) Save(user *entities.User) error {
+	return r.db.Save(user).Error
+}
+
+func (r *gormUserRepository) AdvanceTOTPStep(userID uint, step int64) (bool, error) {
+	result := r.db.Model(&entities.User{}).
+		Where("id = ? AND totp_last_step < ?", userID, step).
+		Update("totp_last_step", step)
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"go-evermos/internal/entities"
+
+	"gorm.io/gorm"
+)
+
+type UserRoleRepository interface {
+	ListByUser(userID uint) ([]entities.UserRole, error)
+	Create(role *entities.UserRole) error
+	Delete(userID uint, role string) error
+	// CountUsers menghitung user aktif (tidak dihapus dan tidak diblokir)
+	// yang punya role tertentu
+	CountUsers(role string) (int64, error)
+}
+
+type gormUserRoleRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormUserRoleRepository) ListByUser(userID uint) ([]entities.UserRole, error) {
+	var roles []entities.UserRole
+	err := r.db.Where("id_user = ?", userID).Order("id").Find(&roles).Error
+	return roles, err
+}
+
+func (r *gormUserRoleRepository) Create(role *entities.UserRole) error {
+	return r.db.Create(role).Error
+}
+
+func (r *gormUserRoleRepository) Delete(userID uint, role string) error {
+	return r.db.Unscoped().Where("id_user = ? AND role = ?", userID, role).Delete(&entities.UserRole{}).Error
+}
+
+func (r *gormUserRoleRepository) CountUsers(role string) (int64, error) {
+	var count int64
+	err := r.db.Model(&entities.UserRole{}).
+		Joins("JOIN `Users` ON `Users`.id = `UserRole`.id_user").
+		Where("`UserRole`.role = ? AND `Users`.deleted_at IS NULL AND `Users`.banned_at IS NULL", role).
+		Count(&count).Error
+	return count, err
+}
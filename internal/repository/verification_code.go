@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"go-evermos/internal/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type VerificationCodeRepository interface {
+	Create(code *entities.VerificationCode) error
+	// FindLatestForUpdate mengambil kode terakhir yang dikirim ke user
+	// lewat channel tertentu
+	FindLatestForUpdate(userID uint, channel string) (*entities.VerificationCode, error)
+	// CountSince menghitung kode yang dikirim sejak waktu tertentu
+	CountSince(userID uint, channel string, since time.Time) (int64, error)
+	Save(code *entities.VerificationCode) error
+}
+
+type gormVerificationCodeRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormVerificationCodeRepository) Create(code *entities.VerificationCode) error {
+	return r.db.Create(code).Error
+}
+
+func (r *gormVerificationCodeRepository) FindLatestForUpdate(userID uint, channel string) (*entities.VerificationCode, error) {
+	var code entities.VerificationCode
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id_user = ? AND channel = ?", userID, channel).
+		Order("id DESC").
+		First(&code).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &code, nil
+}
+
+func (r *gormVerificationCodeRepository) CountSince(userID uint, channel string, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&entities.VerificationCode{}).
+		Where("id_user = ? AND channel = ? AND created_at >= ?", userID, channel, since).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *gormVerificationCodeRepository) Save(code *entities.VerificationCode) error {
+	return r.db.Save(code).Error
+}
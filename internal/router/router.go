@@ -0,0 +1,211 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"go-evermos/internal/entities"
+	"go-evermos/internal/service"
+	"go-evermos/pkg"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Access adalah syarat autentikasi sebuah route
+type Access int
+
+const (
+	// Public bisa diakses tanpa login. Jika ada token valid, principal tetap diisi.
+	Public Access = iota
+	// User wajib login
+	User
+	// Seller wajib login dan punya toko
+	Seller
+	// Admin adalah route back-office: wajib login dan punya izin
+	// Route.Permission
+	Admin
+)
+
+func (a Access) String() string {
+	switch a {
+	case Public:
+		return "public"
+	case User:
+		return "user"
+	case Seller:
+		return "seller"
+	case Admin:
+		return "admin"
+	}
+	return fmt.Sprintf("Access(%d)", int(a))
+}
+
+// AuthHandler adalah handler yang butuh user login. Principal selalu terisi
+// karena route-nya dijamin melewati JWTMiddleware.
+type AuthHandler func(c *fiber.Ctx, p pkg.Principal) error
+
+// Route mendeklarasikan satu endpoint beserta syarat aksesnya.
+// Isi salah satu dari Handler atau Auth.
+type Route struct {
+	Method string
+	Path   string
+	Access Access
+	// Permission adalah izin yang wajib dimiliki user. Wajib untuk route
+	// Admin, opsional untuk route User dan Seller.
+	Permission pkg.Permission
+	Handler    fiber.Handler
+	Auth       AuthHandler
+}
+
+func (r Route) String() string {
+	return r.Method + " " + r.Path
+}
+
+// Validate memeriksa tabel route: tidak ada route ganda, setiap route punya
+// tepat satu handler, handler yang butuh user tidak didaftarkan sebagai
+// public, dan izin hanya dipasang di route yang butuh login.
+func Validate(routes []Route) error {
+	var errs []error
+	seen := map[string]bool{}
+
+	for _, r := range routes {
+		if seen[r.String()] {
+			errs = append(errs, fmt.Errorf("%s: route didaftarkan lebih dari sekali", r))
+		}
+		seen[r.String()] = true
+
+		switch {
+		case r.Handler == nil && r.Auth == nil:
+			errs = append(errs, fmt.Errorf("%s: handler kosong", r))
+		case r.Handler != nil && r.Auth != nil:
+			errs = append(errs, fmt.Errorf("%s: isi Handler atau Auth, bukan keduanya", r))
+		case r.Auth != nil && r.Access == Public:
+			errs = append(errs, fmt.Errorf("%s: handler butuh user login tapi route public", r))
+		}
+		switch {
+		case r.Access == Admin && r.Permission == "":
+			errs = append(errs, fmt.Errorf("%s: route admin wajib punya permission", r))
+		case r.Access == Public && r.Permission != "":
+			errs = append(errs, fmt.Errorf("%s: route public tidak bisa punya permission", r))
+		}
+		if r.Access < Public || r.Access > Admin {
+			errs = append(errs, fmt.Errorf("%s: access %s tidak dikenal", r, r.Access))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// StoreFinder mencari toko milik user untuk route Seller
+type StoreFinder interface {
+	GetByUser(userID uint) (*entities.Store, error)
+}
+
+// AccountPolicy memeriksa kebijakan akun (mis. wajib verifikasi, wajib 2FA)
+// untuk route Seller dan Admin
+type AccountPolicy interface {
+	Allow(userID uint, action service.Action) error
+}
+
+// Deps adalah dependency middleware autentikasi dan otorisasi route
+type Deps struct {
+	Tokens      *pkg.TokenManager
+	Revocations pkg.RevocationChecker
+	Stores      StoreFinder
+	Policy      AccountPolicy
+	Roles       pkg.RoleResolver
+}
+
+// Register memvalidasi lalu mendaftarkan semua route ke app
+func Register(app fiber.Router, routes []Route, deps Deps) error {
+	if err := Validate(routes); err != nil {
+		return err
+	}
+
+	for _, r := range routes {
+		handlers := middlewareFor(r, deps)
+		if r.Auth != nil {
+			handlers = append(handlers, withPrincipal(r.Auth))
+		} else {
+			handlers = append(handlers, r.Handler)
+		}
+		app.Add(r.Method, r.Path, handlers...)
+	}
+	return nil
+}
+
+func middlewareFor(r Route, deps Deps) []fiber.Handler {
+	auth := pkg.JWTMiddleware(deps.Tokens, deps.Revocations)
+	var handlers []fiber.Handler
+	switch r.Access {
+	case User:
+		handlers = []fiber.Handler{auth}
+	case Seller:
+		handlers = []fiber.Handler{auth, allow(deps.Policy, service.ActionOpenStore), sellerOnly(deps.Stores)}
+	case Admin:
+		handlers = []fiber.Handler{auth}
+	default:
+		return []fiber.Handler{pkg.OptionalJWTMiddleware(deps.Tokens, deps.Revocations)}
+	}
+
+	if r.Permission != "" {
+		handlers = append(handlers, pkg.RequirePermission(deps.Roles, r.Permission))
+	}
+	if r.Access == Admin {
+		handlers = append(handlers, allow(deps.Policy, service.ActionAdmin))
+	}
+	return handlers
+}
+
+// withPrincipal meneruskan principal ke handler, menolak dengan 401 jika tidak ada
+func withPrincipal(h AuthHandler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		p, err := pkg.RequirePrincipal(c)
+		if err != nil {
+			return err
+		}
+		return h(c, p)
+	}
+}
+
+// allow menolak request jika kebijakan akun tidak mengizinkan aksi
+func allow(policy AccountPolicy, action service.Action) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		p, err := pkg.RequirePrincipal(c)
+		if err != nil {
+			return err
+		}
+
+		if err := policy.Allow(p.UserID, action); err != nil {
+			var e *service.Error
+			if errors.As(err, &e) {
+				return c.Status(e.Status).JSON(fiber.Map{"error": e.Message})
+			}
+			return err
+		}
+		return c.Next()
+	}
+}
+
+// sellerOnly memastikan user login punya toko. Token lama yang belum
+// membawa store_id dilengkapi dari database.
+func sellerOnly(stores StoreFinder) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		p, err := pkg.RequirePrincipal(c)
+		if err != nil {
+			return err
+		}
+
+		if p.StoreID == 0 {
+			store, err := stores.GetByUser(p.UserID)
+			if err != nil {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Toko tidak ditemukan"})
+			}
+			p.StoreID = store.ID
+			if !p.HasRole(pkg.RoleSeller) {
+				p.Roles = append(p.Roles, pkg.RoleSeller)
+			}
+			pkg.SetPrincipal(c, p)
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,87 @@
+package router
+
+import (
+	"go-evermos/internal/handler"
+	"go-evermos/pkg"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func publicHandler(c *fiber.Ctx) error { return nil }
+
+func authHandler(c *fiber.Ctx, p pkg.Principal) error { return nil }
+
+func TestRoutesValid(t *testing.T) {
+	if err := Validate(Routes(handler.Handlers{})); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateRejectsMisdeclaredRoute(t *testing.T) {
+	valid := Route{Method: fiber.MethodGet, Path: "/ok", Access: User, Auth: authHandler}
+
+	cases := []struct {
+		name  string
+		route Route
+		want  string
+	}{
+		{
+			"route ganda",
+			valid,
+			"GET /ok: route didaftarkan lebih dari sekali",
+		},
+		{
+			"tanpa handler",
+			Route{Method: fiber.MethodGet, Path: "/kosong", Access: User},
+			"handler kosong",
+		},
+		{
+			"dua handler",
+			Route{Method: fiber.MethodGet, Path: "/dua", Access: User, Handler: publicHandler, Auth: authHandler},
+			"isi Handler atau Auth, bukan keduanya",
+		},
+		{
+			"handler butuh login di route public",
+			Route{Method: fiber.MethodGet, Path: "/public", Access: Public, Auth: authHandler},
+			"handler butuh user login tapi route public",
+		},
+		{
+			"admin tanpa permission",
+			Route{Method: fiber.MethodGet, Path: "/admin", Access: Admin, Auth: authHandler},
+			"route admin wajib punya permission",
+		},
+		{
+			"permission di route public",
+			Route{Method: fiber.MethodGet, Path: "/public", Access: Public, Permission: pkg.PermUserBan, Handler: publicHandler},
+			"route public tidak bisa punya permission",
+		},
+		{
+			"access tidak dikenal",
+			Route{Method: fiber.MethodGet, Path: "/aneh", Access: Admin + 1, Auth: authHandler},
+			"access Access(4) tidak dikenal",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate([]Route{valid, tc.route})
+			if err == nil {
+				t.Fatal("Validate berhasil")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("error %q tidak memuat %q", err, tc.want)
+			}
+		})
+	}
+
+	// method yang sama dengan path berbeda (dan sebaliknya) bukan route ganda
+	err := Validate([]Route{
+		valid,
+		{Method: fiber.MethodPost, Path: "/ok", Access: User, Auth: authHandler},
+		{Method: fiber.MethodGet, Path: "/ok/lain", Access: Admin, Permission: pkg.PermUserBan, Auth: authHandler},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
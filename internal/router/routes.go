@@ -0,0 +1,101 @@
+package router
+
+import (
+	"go-evermos/internal/handler"
+	"go-evermos/pkg"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Routes adalah daftar semua endpoint API beserta syarat aksesnya
+func Routes(h handler.Handlers) []Route {
+	return []Route{
+		{Method: fiber.MethodGet, Path: "/", Access: Public, Handler: func(c *fiber.Ctx) error {
+			return c.SendString("API Ecommerce Jalan")
+		}},
+
+		// Probe untuk orchestrator
+		{Method: fiber.MethodGet, Path: "/healthz", Access: Public, Handler: h.Health.Healthz},
+		{Method: fiber.MethodGet, Path: "/readyz", Access: Public, Handler: h.Health.Readyz},
+		{Method: fiber.MethodGet, Path: "/version", Access: Public, Handler: h.Health.Version},
+
+		// Auth
+		{Method: fiber.MethodPost, Path: "/register", Access: Public, Handler: h.User.Register},
+		{Method: fiber.MethodPost, Path: "/login", Access: Public, Handler: h.Auth.Login},
+		{Method: fiber.MethodPost, Path: "/auth/2fa", Access: Public, Handler: h.Auth.VerifyTwoFactor},
+		{Method: fiber.MethodPost, Path: "/auth/refresh", Access: Public, Handler: h.Auth.Refresh},
+		{Method: fiber.MethodPost, Path: "/auth/logout", Access: User, Auth: h.Auth.Logout},
+		{Method: fiber.MethodPost, Path: "/auth/logout-all", Access: User, Auth: h.Auth.LogoutAll},
+		{Method: fiber.MethodPost, Path: "/auth/forgot-password", Access: Public, Handler: h.Auth.ForgotPassword},
+		{Method: fiber.MethodPost, Path: "/auth/reset-password", Access: Public, Handler: h.Auth.ResetPassword},
+		{Method: fiber.MethodGet, Path: "/.well-known/jwks.json", Access: Public, Handler: h.Auth.JWKS},
+
+		// User
+		{Method: fiber.MethodGet, Path: "/user/profile", Access: User, Auth: h.User.Profile},
+		{Method: fiber.MethodPut, Path: "/user/profile", Access: User, Auth: h.User.UpdateProfile},
+		{Method: fiber.MethodPut, Path: "/user/password", Access: User, Auth: h.Auth.ChangePassword},
+		{Method: fiber.MethodPost, Path: "/user/verify/:channel/send", Access: User, Auth: h.Verification.SendCode},
+		{Method: fiber.MethodPost, Path: "/user/verify/:channel", Access: User, Auth: h.Verification.Verify},
+		{Method: fiber.MethodGet, Path: "/user/2fa", Access: User, Auth: h.TwoFactor.Status},
+		{Method: fiber.MethodPost, Path: "/user/2fa/setup", Access: User, Auth: h.TwoFactor.Setup},
+		{Method: fiber.MethodPost, Path: "/user/2fa/enable", Access: User, Auth: h.TwoFactor.Enable},
+		{Method: fiber.MethodPost, Path: "/user/2fa/disable", Access: User, Auth: h.TwoFactor.Disable},
+		{Method: fiber.MethodPost, Path: "/user/2fa/recovery-codes", Access: User, Auth: h.TwoFactor.RegenerateRecoveryCodes},
+
+		// Toko
+		{Method: fiber.MethodGet, Path: "/store", Access: Seller, Auth: h.Store.GetMyStore},
+		{Method: fiber.MethodPut, Path: "/store", Access: Seller, Auth: h.Store.UpdateMyStore},
+		{Method: fiber.MethodGet, Path: "/store/products", Access: Seller, Auth: h.Product.GetMyProducts},
+		{Method: fiber.MethodPost, Path: "/store/products/import", Access: Seller, Auth: h.Import.ImportProducts},
+		{Method: fiber.MethodGet, Path: "/store/products/import/:id", Access: Seller, Auth: h.Import.GetImportJob},
+		{Method: fiber.MethodGet, Path: "/store/products/export", Access: Seller, Auth: h.Import.ExportProducts},
+		{Method: fiber.MethodGet, Path: "/store/stock/reconcile", Access: Seller, Auth: h.Product.ReconcileStock},
+
+		// Alamat
+		{Method: fiber.MethodPost, Path: "/address", Access: User, Auth: h.Address.CreateAddress},
+		{Method: fiber.MethodGet, Path: "/address", Access: User, Auth: h.Address.GetAddresses},
+		{Method: fiber.MethodPut, Path: "/address/:id", Access: User, Auth: h.Address.UpdateAddress},
+		{Method: fiber.MethodDelete, Path: "/address/:id", Access: User, Auth: h.Address.DeleteAddress},
+
+		// Kategori (admin dan moderator katalog)
+		{Method: fiber.MethodPost, Path: "/categories", Access: Admin, Permission: pkg.PermCategoryManage, Handler: h.Category.CreateCategory},
+		{Method: fiber.MethodGet, Path: "/categories", Access: Admin, Permission: pkg.PermCategoryManage, Handler: h.Category.GetCategories},
+		{Method: fiber.MethodPut, Path: "/categories/:id", Access: Admin, Permission: pkg.PermCategoryManage, Handler: h.Category.UpdateCategory},
+		{Method: fiber.MethodDelete, Path: "/categories/:id", Access: Admin, Permission: pkg.PermCategoryManage, Handler: h.Category.DeleteCategory},
+
+		// Produk
+		{Method: fiber.MethodGet, Path: "/products", Access: Public, Handler: h.Product.GetAllProducts},
+		{Method: fiber.MethodGet, Path: "/product/:id", Access: Public, Handler: h.Product.GetProductByID},
+		{Method: fiber.MethodPost, Path: "/product", Access: Seller, Auth: h.Product.CreateProduct},
+		{Method: fiber.MethodPut, Path: "/product/:id", Access: Seller, Auth: h.Product.UpdateProduct},
+		{Method: fiber.MethodDelete, Path: "/product/:id", Access: Seller, Auth: h.Product.DeleteProduct},
+		{Method: fiber.MethodPut, Path: "/product/:id/status", Access: Seller, Auth: h.Product.UpdateProductStatus},
+		{Method: fiber.MethodGet, Path: "/product/:id/stock", Access: Seller, Auth: h.Product.GetStockHistory},
+		{Method: fiber.MethodPost, Path: "/product/:id/stock", Access: Seller, Auth: h.Product.AdjustStock},
+		{Method: fiber.MethodPost, Path: "/product/:id/subscribe", Access: User, Auth: h.Product.SubscribeRestock},
+		{Method: fiber.MethodDelete, Path: "/product/:id/subscribe", Access: User, Auth: h.Product.UnsubscribeRestock},
+
+		// Notifikasi
+		{Method: fiber.MethodGet, Path: "/notifications", Access: User, Auth: h.Notification.GetNotifications},
+		{Method: fiber.MethodPut, Path: "/notifications/:id/read", Access: User, Auth: h.Notification.ReadNotification},
+
+		// Admin
+		{Method: fiber.MethodPut, Path: "/admin/product/:id/ban", Access: Admin, Permission: pkg.PermProductModerate, Handler: h.Product.BanProduct},
+		{Method: fiber.MethodPut, Path: "/admin/product/:id/unban", Access: Admin, Permission: pkg.PermProductModerate, Handler: h.Product.UnbanProduct},
+		{Method: fiber.MethodPut, Path: "/admin/user/:id/ban", Access: Admin, Permission: pkg.PermUserBan, Auth: h.User.BanUser},
+		{Method: fiber.MethodPut, Path: "/admin/user/:id/unban", Access: Admin, Permission: pkg.PermUserBan, Handler: h.User.UnbanUser},
+		{Method: fiber.MethodGet, Path: "/admin/login-attempts", Access: Admin, Permission: pkg.PermLoginAttemptRead, Handler: h.Auth.LoginAttempts},
+		{Method: fiber.MethodGet, Path: "/admin/transactions", Access: Admin, Permission: pkg.PermTransactionRead, Handler: h.Transaction.GetAllTransactions},
+		{Method: fiber.MethodPost, Path: "/admin/transactions/:id/pay", Access: Admin, Permission: pkg.PermTransactionConfirm, Handler: h.Transaction.PayTransaction},
+		{Method: fiber.MethodGet, Path: "/admin/roles", Access: Admin, Permission: pkg.PermRoleManage, Handler: h.Role.Catalog},
+		{Method: fiber.MethodGet, Path: "/admin/user/:id/roles", Access: Admin, Permission: pkg.PermRoleManage, Handler: h.Role.UserRoles},
+		{Method: fiber.MethodPost, Path: "/admin/user/:id/roles", Access: Admin, Permission: pkg.PermRoleManage, Auth: h.Role.Grant},
+		{Method: fiber.MethodDelete, Path: "/admin/user/:id/roles/:role", Access: Admin, Permission: pkg.PermRoleManage, Handler: h.Role.Revoke},
+
+		// Transaksi
+		{Method: fiber.MethodPost, Path: "/transactions", Access: User, Permission: pkg.PermCheckout, Auth: h.Transaction.CreateTransaction},
+		{Method: fiber.MethodGet, Path: "/transactions", Access: User, Auth: h.Transaction.GetUserTransactions},
+		{Method: fiber.MethodGet, Path: "/transactions/:id", Access: User, Auth: h.Transaction.GetUserTransactionByID},
+		{Method: fiber.MethodPost, Path: "/transactions/:id/cancel", Access: User, Auth: h.Transaction.CancelTransaction},
+	}
+}
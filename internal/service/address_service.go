@@ -0,0 +1,69 @@
+package service
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+)
+
+type AddressInput struct {
+	JudulAlamat  string `json:"judul_alamat"`
+	NamaPenerima string `json:"nama_penerima"`
+	NoTelp       string `json:"no_telp"`
+	DetailAlamat string `json:"detail_alamat"`
+}
+
+type AddressService struct {
+	repos *repository.Repositories
+}
+
+func NewAddressService(repos *repository.Repositories) *AddressService {
+	return &AddressService{repos: repos}
+}
+
+func (s *AddressService) Create(userID uint, input AddressInput) (*entities.Address, error) {
+	address := entities.Address{
+		IDUser:       userID,
+		JudulAlamat:  input.JudulAlamat,
+		NamaPenerima: input.NamaPenerima,
+		NoTelp:       input.NoTelp,
+		DetailAlamat: input.DetailAlamat,
+	}
+	if err := s.repos.Addresses.Create(&address); err != nil {
+		return nil, err
+	}
+	return &address, nil
+}
+
+func (s *AddressService) List(userID uint, filter repository.AddressFilter) ([]entities.Address, error) {
+	return s.repos.Addresses.ListForUser(userID, filter)
+}
+
+// Update mengubah alamat milik user. Field kosong tidak diubah.
+func (s *AddressService) Update(userID, id uint, input AddressInput) (*entities.Address, error) {
+	address, err := s.repos.Addresses.FindForUser(id, userID)
+	if err != nil {
+		return nil, orNotFound(err, "Alamat tidak ditemukan")
+	}
+
+	if input.JudulAlamat != "" {
+		address.JudulAlamat = input.JudulAlamat
+	}
+	if input.NamaPenerima != "" {
+		address.NamaPenerima = input.NamaPenerima
+	}
+	if input.NoTelp != "" {
+		address.NoTelp = input.NoTelp
+	}
+	if input.DetailAlamat != "" {
+		address.DetailAlamat = input.DetailAlamat
+	}
+
+	if err := s.repos.Addresses.Save(address); err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+func (s *AddressService) Delete(userID, id uint) error {
+	return s.repos.Addresses.DeleteForUser(id, userID)
+}
@@ -0,0 +1,411 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"go-evermos/pkg"
+	"log"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TokenPair adalah hasil login atau refresh
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	// ExpiresIn adalah masa berlaku access token
+	ExpiresIn time.Duration
+}
+
+// LoginResult adalah hasil login: pasangan token, atau challenge jika user
+// memakai 2FA
+type LoginResult struct {
+	Tokens *TokenPair
+	// Challenge ditukar dengan pasangan token lewat VerifyTwoFactor
+	Challenge          string
+	ChallengeExpiresIn time.Duration
+}
+
+type AuthService struct {
+	repos      *repository.Repositories
+	tokens     *pkg.TokenManager
+	refreshTTL time.Duration
+	limits     LoginLimits
+}
+
+func NewAuthService(repos *repository.Repositories, tokens *pkg.TokenManager, refreshTTL time.Duration, limits LoginLimits) *AuthService {
+	return &AuthService{repos: repos, tokens: tokens, refreshTTL: refreshTTL, limits: limits}
+}
+
+// Login memeriksa email atau no telp dan password lalu membuka sesi baru.
+// Jika user memakai 2FA, yang dikembalikan adalah challenge untuk
+// VerifyTwoFactor. Login gagal berturut-turut per akun maupun per IP
+// diperlambat (backoff) lalu dikunci sementara; semua percobaan dicatat di
+// LoginAttempt.
+func (s *AuthService) Login(identifier, password string, client LoginClient) (*LoginResult, error) {
+	user, err := s.findLoginUser(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	key := loginKey(identifier, user)
+	wait, err := s.loginWait(s.repos, key, client.IP, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if wait > 0 {
+		s.recordLogin(key, nil, client, entities.LoginLocked)
+		return nil, retryLater("Terlalu banyak percobaan login", wait)
+	}
+
+	if user == nil {
+		bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+		s.recordLogin(key, nil, client, entities.LoginFailed)
+		return nil, errInvalidLogin
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.KataSandi), []byte(password)); err != nil {
+		s.recordLogin(key, user, client, entities.LoginFailed)
+		return nil, errInvalidLogin
+	}
+	if user.BannedAt != nil {
+		s.recordLogin(key, user, client, entities.LoginBanned)
+		return nil, forbidden("Akun diblokir")
+	}
+
+	if twoFactorEnabled(user) {
+		raw := newOpaqueToken()
+		if err := s.repos.LoginChallenges.Create(&entities.LoginChallenge{
+			IDUser:    user.ID,
+			TokenHash: hashToken(raw),
+			ExpiresAt: time.Now().Add(challengeTTL),
+		}); err != nil {
+			return nil, err
+		}
+		s.recordLogin(key, user, client, entities.LoginTwoFactor)
+		return &LoginResult{Challenge: raw, ChallengeExpiresIn: challengeTTL}, nil
+	}
+
+	var pair *TokenPair
+	err = s.repos.Transaction(func(tx *repository.Repositories) error {
+		pair, err = s.issue(tx, user, newSessionID())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.recordLogin(key, user, client, entities.LoginSuccess)
+	return &LoginResult{Tokens: pair}, nil
+}
+
+// VerifyTwoFactor menukar challenge dari Login dan kode 2FA (TOTP atau kode
+// cadangan) dengan pasangan token. Kode salah dihitung sebagai login gagal
+// sehingga ikut kena backoff dan lockout.
+func (s *AuthService) VerifyTwoFactor(challenge, code string, client LoginClient) (*TokenPair, error) {
+	var (
+		pair  *TokenPair
+		user  *entities.User
+		wrong bool
+		wait  time.Duration
+	)
+	now := time.Now()
+	invalid := unauthorized("Sesi login 2FA tidak valid atau sudah kedaluwarsa, silakan login ulang")
+
+	err := s.repos.Transaction(func(tx *repository.Repositories) error {
+		ch, err := tx.LoginChallenges.FindByHashForUpdate(hashToken(challenge))
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return invalid
+			}
+			return err
+		}
+		if ch.UsedAt != nil || now.After(ch.ExpiresAt) || ch.Attempts >= maxChallengeAttempts {
+			return invalid
+		}
+
+		user, err = tx.Users.FindByID(ch.IDUser)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return invalid
+			}
+			return err
+		}
+		if user.BannedAt != nil {
+			return forbidden("Akun diblokir")
+		}
+
+		if wait, err = s.loginWait(tx, normalizeLoginEmail(user.Email), client.IP, now); err != nil || wait > 0 {
+			return err
+		}
+
+		ok, err := verifySecondFactor(tx, user, code, now)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			// Percobaan salah tetap disimpan (transaksi di-commit)
+			wrong = true
+			ch.Attempts++
+			return tx.LoginChallenges.Save(ch)
+		}
+
+		ch.UsedAt = &now
+		if err := tx.LoginChallenges.Save(ch); err != nil {
+			return err
+		}
+		pair, err = s.issue(tx, user, newSessionID())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	key := normalizeLoginEmail(user.Email)
+	switch {
+	case wait > 0:
+		s.recordLogin(key, user, client, entities.LoginLocked)
+		return nil, retryLater("Terlalu banyak percobaan login", wait)
+	case wrong:
+		s.recordLogin(key, user, client, entities.LoginFailed)
+		return nil, unauthorized("Kode 2FA salah")
+	}
+	s.recordLogin(key, user, client, entities.LoginSuccess)
+	return pair, nil
+}
+
+// Refresh menukar refresh token dengan pasangan token baru. Refresh token
+// hanya berlaku sekali; jika token yang sudah dirotasi dipakai lagi, berarti
+// token tersebut bocor, sehingga seluruh sesinya dicabut.
+func (s *AuthService) Refresh(raw string) (*TokenPair, error) {
+	var (
+		pair   *TokenPair
+		reused *entities.RefreshToken
+	)
+	now := time.Now()
+
+	err := s.repos.Transaction(func(tx *repository.Repositories) error {
+		token, err := tx.RefreshTokens.FindByHashForUpdate(hashToken(raw))
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return unauthorized("Refresh token tidak valid")
+			}
+			return err
+		}
+
+		switch {
+		case token.RevokedAt != nil:
+			return unauthorized("Sesi sudah berakhir, silakan login ulang")
+		case token.RotatedAt != nil:
+			reused = token
+			return unauthorized("Sesi sudah berakhir, silakan login ulang")
+		case now.After(token.ExpiresAt):
+			return unauthorized("Refresh token sudah kedaluwarsa")
+		}
+
+		if err := tx.RefreshTokens.MarkRotated(token.ID, now); err != nil {
+			return err
+		}
+
+		// Role bisa berubah sejak login (mis. toko baru dibuat), jadi data
+		// user dibaca ulang
+		user, err := tx.Users.FindByID(token.IDUser)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return unauthorized("Refresh token tidak valid")
+			}
+			return err
+		}
+		if user.BannedAt != nil {
+			return forbidden("Akun diblokir")
+		}
+
+		pair, err = s.issue(tx, user, token.Family)
+		return err
+	})
+
+	// Pencabutan dilakukan setelah transaksi di atas di-rollback
+	if reused != nil {
+		log.Printf("refresh token dipakai ulang: user %d, sesi %s dicabut", reused.IDUser, reused.Family)
+		if err := s.repos.RefreshTokens.RevokeFamily(reused.Family, now); err != nil {
+			return nil, err
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pair, nil
+}
+
+// Logout mencabut access token yang sedang dipakai beserta sesi refresh
+// token-nya. Perangkat lain tetap login.
+func (s *AuthService) Logout(p pkg.Principal) error {
+	now := time.Now()
+	return s.repos.Transaction(func(tx *repository.Repositories) error {
+		if p.TokenID != "" {
+			// Access token paling lama berlaku TTL sejak diterbitkan, jadi
+			// catatannya aman dihapus setelah itu
+			if err := tx.RevokedTokens.Create(&entities.RevokedToken{
+				JTI:       p.TokenID,
+				IDUser:    p.UserID,
+				ExpiresAt: now.Add(s.tokens.TTL()),
+			}); err != nil {
+				return err
+			}
+		}
+		if p.SessionID != "" {
+			if err := tx.RefreshTokens.RevokeFamily(p.SessionID, now); err != nil {
+				return err
+			}
+		}
+		return tx.RevokedTokens.DeleteExpired(now)
+	})
+}
+
+// LogoutAll mencabut semua token user di semua perangkat
+func (s *AuthService) LogoutAll(userID uint) error {
+	return s.repos.Transaction(func(tx *repository.Repositories) error {
+		user, err := tx.Users.FindByID(userID)
+		if err != nil {
+			return orNotFound(err, "User tidak ditemukan")
+		}
+		return revokeAllSessions(tx, user)
+	})
+}
+
+// ChangePassword mengganti password lalu mencabut semua sesi lama. Perangkat
+// yang mengganti password langsung mendapat sesi baru.
+func (s *AuthService) ChangePassword(userID uint, oldPassword, newPassword string) (*TokenPair, error) {
+	if newPassword == "" {
+		return nil, badRequest("Password baru wajib diisi")
+	}
+
+	var pair *TokenPair
+	err := s.repos.Transaction(func(tx *repository.Repositories) error {
+		user, err := tx.Users.FindByID(userID)
+		if err != nil {
+			return orNotFound(err, "User tidak ditemukan")
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(user.KataSandi), []byte(oldPassword)); err != nil {
+			return badRequest("Password lama salah")
+		}
+
+		hashed, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+		if err != nil {
+			return err
+		}
+		user.KataSandi = string(hashed)
+
+		if err := revokeAllSessions(tx, user); err != nil {
+			return err
+		}
+		pair, err = s.issue(tx, user, newSessionID())
+		return err
+	})
+	return pair, err
+}
+
+// Revoked dipakai JWTMiddleware untuk menolak token yang sudah dicabut:
+// user dihapus atau diblokir, versi token sudah lama, jti dicabut lewat
+// logout, atau sesinya sudah dicabut.
+func (s *AuthService) Revoked(claims *pkg.JWTClaim) (bool, error) {
+	user, err := s.repos.Users.FindByID(claims.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return true, nil
+		}
+		return false, err
+	}
+	if user.BannedAt != nil || claims.Version != user.TokenVersion {
+		return true, nil
+	}
+
+	if claims.ID != "" {
+		if revoked, err := s.repos.RevokedTokens.Exists(claims.ID); err != nil || revoked {
+			return revoked, err
+		}
+	}
+	if claims.SessionID != "" {
+		return s.repos.RefreshTokens.FamilyRevoked(claims.SessionID)
+	}
+	return false, nil
+}
+
+// JWKS adalah kunci publik untuk memverifikasi access token
+func (s *AuthService) JWKS() pkg.JWKSet {
+	return s.tokens.JWKS()
+}
+
+// revokeAllSessions menaikkan versi token user (semua access token lama
+// langsung ditolak) dan mencabut semua refresh token-nya. user ikut disimpan.
+func revokeAllSessions(tx *repository.Repositories, user *entities.User) error {
+	user.TokenVersion++
+	if err := tx.Users.Save(user); err != nil {
+		return err
+	}
+	return tx.RefreshTokens.RevokeUser(user.ID, time.Now())
+}
+
+// issue membuat access token dan refresh token baru dalam sesi family
+func (s *AuthService) issue(tx *repository.Repositories, user *entities.User, family string) (*TokenPair, error) {
+	var storeID uint
+	if store, err := tx.Stores.FindByUserID(user.ID); err == nil {
+		storeID = store.ID
+	}
+	granted, err := grantedRoles(tx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	access, err := s.tokens.Generate(pkg.TokenSubject{
+		UserID:    user.ID,
+		StoreID:   storeID,
+		Roles:     pkg.UserRoles(storeID != 0, granted),
+		SessionID: family,
+		Version:   user.TokenVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	raw := newOpaqueToken()
+	if err := tx.RefreshTokens.Create(&entities.RefreshToken{
+		IDUser:    user.ID,
+		Family:    family,
+		TokenHash: hashToken(raw),
+		ExpiresAt: time.Now().Add(s.refreshTTL),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  access,
+		RefreshToken: raw,
+		ExpiresIn:    s.tokens.TTL(),
+	}, nil
+}
+
+// newOpaqueToken membuat token acak 256 bit (refresh token, token reset)
+func newOpaqueToken() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func newSessionID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// hashToken menghasilkan hash token yang disimpan di database. Cukup
+// SHA-256 karena token sudah acak penuh, tidak perlu bcrypt.
+func hashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"go-evermos/pkg"
+	"net/http"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const testPassword = "rahasia123"
+
+var testClient = LoginClient{IP: "10.0.0.1", UserAgent: "test"}
+
+// newTestAuth membuat AuthService dengan token HS256. limits kosong diganti
+// batas bawaan test.
+func newTestAuth(repos *repository.Repositories, limits LoginLimits) *AuthService {
+	if limits == (LoginLimits{}) {
+		limits = LoginLimits{MaxAttempts: 5, IPMaxAttempts: 20, Lockout: time.Minute}
+	}
+	tokens := pkg.NewTokenManager("secret-untuk-test", pkg.TokenOptions{
+		TTL:      15 * time.Minute,
+		Issuer:   "go-evermos",
+		Audience: "go-evermos-api",
+		Leeway:   30 * time.Second,
+	})
+	return NewAuthService(repos, tokens, time.Hour, limits)
+}
+
+// seedLoginUser membuat user dengan password testPassword
+func seedLoginUser(t *testing.T, repos *repository.Repositories, nama string) *entities.User {
+	t.Helper()
+
+	user, _ := seedUser(t, repos, nama)
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user.KataSandi = string(hash)
+	if err := repos.Users.Save(user); err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+func login(t *testing.T, s *AuthService, user *entities.User) *TokenPair {
+	t.Helper()
+
+	res, err := s.Login(user.Email, testPassword, testClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res.Tokens
+}
+
+// principal memvalidasi access token seperti JWTMiddleware
+func principal(t *testing.T, s *AuthService, access string) (pkg.Principal, *pkg.JWTClaim) {
+	t.Helper()
+
+	claims, err := s.tokens.Validate(access)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkg.Principal{UserID: claims.UserID, TokenID: claims.ID, SessionID: claims.SessionID}, claims
+}
+
+// assertRevoked memastikan status pencabutan access token
+func assertRevoked(t *testing.T, s *AuthService, access string, want bool) {
+	t.Helper()
+
+	_, claims := principal(t, s, access)
+	revoked, err := s.Revoked(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revoked != want {
+		t.Errorf("access token dicabut = %v, seharusnya %v", revoked, want)
+	}
+}
+
+func TestRefreshRotatesToken(t *testing.T) {
+	repos := newTestRepos()
+	user := seedLoginUser(t, repos, "user")
+	s := newTestAuth(repos, LoginLimits{})
+
+	first := login(t, s, user)
+	second, err := s.Refresh(first.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.RefreshToken == first.RefreshToken {
+		t.Fatal("refresh token tidak dirotasi")
+	}
+	_, a := principal(t, s, first.AccessToken)
+	_, b := principal(t, s, second.AccessToken)
+	if a.SessionID != b.SessionID {
+		t.Errorf("sesi berubah dari %s ke %s, seharusnya tetap", a.SessionID, b.SessionID)
+	}
+	assertRevoked(t, s, second.AccessToken, false)
+
+	third, err := s.Refresh(second.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRevoked(t, s, third.AccessToken, false)
+}
+
+func TestRefreshReuseRevokesFamily(t *testing.T) {
+	repos := newTestRepos()
+	user := seedLoginUser(t, repos, "user")
+	s := newTestAuth(repos, LoginLimits{})
+
+	stolen := login(t, s, user)
+	other := login(t, s, user)
+	rotated, err := s.Refresh(stolen.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// refresh token lama dipakai lagi: seluruh sesi dicabut
+	_, err = s.Refresh(stolen.RefreshToken)
+	assertStatus(t, err, http.StatusUnauthorized)
+	_, err = s.Refresh(rotated.RefreshToken)
+	assertStatus(t, err, http.StatusUnauthorized)
+	assertRevoked(t, s, stolen.AccessToken, true)
+	assertRevoked(t, s, rotated.AccessToken, true)
+
+	// sesi di perangkat lain tidak ikut dicabut
+	assertRevoked(t, s, other.AccessToken, false)
+	if _, err := s.Refresh(other.RefreshToken); err != nil {
+		t.Errorf("sesi lain ikut dicabut: %v", err)
+	}
+}
+
+func TestRefreshRejectsInvalidToken(t *testing.T) {
+	repos := newTestRepos()
+	user := seedLoginUser(t, repos, "user")
+	s := newTestAuth(repos, LoginLimits{})
+	pair := login(t, s, user)
+
+	_, err := s.Refresh("token-acak")
+	assertStatus(t, err, http.StatusUnauthorized)
+
+	now := time.Now()
+	user.BannedAt = &now
+	if err := repos.Users.Save(user); err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Refresh(pair.RefreshToken)
+	assertStatus(t, err, http.StatusForbidden)
+}
+
+func TestLogoutRevokesOnlyCurrentSession(t *testing.T) {
+	repos := newTestRepos()
+	user := seedLoginUser(t, repos, "user")
+	s := newTestAuth(repos, LoginLimits{})
+
+	current := login(t, s, user)
+	other := login(t, s, user)
+	p, _ := principal(t, s, current.AccessToken)
+	if err := s.Logout(p); err != nil {
+		t.Fatal(err)
+	}
+
+	assertRevoked(t, s, current.AccessToken, true)
+	_, err := s.Refresh(current.RefreshToken)
+	assertStatus(t, err, http.StatusUnauthorized)
+	assertRevoked(t, s, other.AccessToken, false)
+}
+
+func TestSessionsRevokedForWholeAccount(t *testing.T) {
+	cases := []struct {
+		name string
+		// revoke mencabut semua sesi user dan mengembalikan sesi baru jika ada
+		revoke func(t *testing.T, repos *repository.Repositories, s *AuthService, user *entities.User) *TokenPair
+	}{
+		{"logout semua perangkat", func(t *testing.T, _ *repository.Repositories, s *AuthService, user *entities.User) *TokenPair {
+			if err := s.LogoutAll(user.ID); err != nil {
+				t.Fatal(err)
+			}
+			return nil
+		}},
+		{"ganti password", func(t *testing.T, _ *repository.Repositories, s *AuthService, user *entities.User) *TokenPair {
+			_, err := s.ChangePassword(user.ID, "salah", "baru12345")
+			assertStatus(t, err, http.StatusBadRequest)
+			pair, err := s.ChangePassword(user.ID, testPassword, "baru12345")
+			if err != nil {
+				t.Fatal(err)
+			}
+			return pair
+		}},
+		{"diblokir", func(t *testing.T, repos *repository.Repositories, _ *AuthService, user *entities.User) *TokenPair {
+			support, _ := seedUser(t, repos, "support")
+			grantRole(t, NewRoleService(repos), support.ID, pkg.RoleSupport)
+			if _, err := NewUserService(repos, nil).Ban(support.ID, user.ID, "spam"); err != nil {
+				t.Fatal(err)
+			}
+			return nil
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repos := newTestRepos()
+			user := seedLoginUser(t, repos, "user")
+			s := newTestAuth(repos, LoginLimits{})
+			sessions := []*TokenPair{login(t, s, user), login(t, s, user)}
+
+			fresh := tc.revoke(t, repos, s, user)
+
+			for _, pair := range sessions {
+				assertRevoked(t, s, pair.AccessToken, true)
+				_, err := s.Refresh(pair.RefreshToken)
+				assertStatus(t, err, http.StatusUnauthorized)
+			}
+			if fresh != nil {
+				assertRevoked(t, s, fresh.AccessToken, false)
+			}
+		})
+	}
+}
+
+func TestChangePasswordRequiresNewPassword(t *testing.T) {
+	repos := newTestRepos()
+	user := seedLoginUser(t, repos, "user")
+	s := newTestAuth(repos, LoginLimits{})
+
+	_, err := s.ChangePassword(user.ID, testPassword, "")
+	assertStatus(t, err, http.StatusBadRequest)
+	if _, err := s.Login(user.Email, testPassword, testClient); err != nil {
+		t.Errorf("password lama tidak berlaku lagi: %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+)
+
+type CategoryService struct {
+	repos *repository.Repositories
+}
+
+func NewCategoryService(repos *repository.Repositories) *CategoryService {
+	return &CategoryService{repos: repos}
+}
+
+func (s *CategoryService) Create(nama string) (*entities.Category, error) {
+	category := entities.Category{NamaCategory: nama}
+	if err := s.repos.Categories.Create(&category); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (s *CategoryService) List(filter repository.CategoryFilter) ([]entities.Category, error) {
+	return s.repos.Categories.List(filter)
+}
+
+func (s *CategoryService) Update(id uint, nama string) (*entities.Category, error) {
+	category, err := s.repos.Categories.FindByID(id)
+	if err != nil {
+		return nil, orNotFound(err, "Kategori tidak ditemukan")
+	}
+
+	category.NamaCategory = nama
+	if err := s.repos.Categories.Save(category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (s *CategoryService) Delete(id uint) error {
+	return s.repos.Categories.Delete(id)
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"go-evermos/internal/repository"
+	"net/http"
+	"time"
+)
+
+// Error adalah error bisnis dengan status HTTP dan pesan yang aman
+// ditampilkan ke user. Error lain dari service dianggap error internal.
+type Error struct {
+	Status  int
+	Message string
+	// RetryAfter diisi jika client baru boleh mengulang request setelah
+	// jeda tertentu (dikirim sebagai header Retry-After)
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func newError(status int, message string) *Error {
+	return &Error{Status: status, Message: message}
+}
+
+func badRequest(message string) *Error {
+	return newError(http.StatusBadRequest, message)
+}
+
+func forbidden(message string) *Error {
+	return newError(http.StatusForbidden, message)
+}
+
+func notFound(message string) *Error {
+	return newError(http.StatusNotFound, message)
+}
+
+// orNotFound mengubah repository.ErrNotFound menjadi error 404 dengan pesan
+// tertentu; error lain diteruskan apa adanya
+func orNotFound(err error, message string) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return notFound(message)
+	}
+	return err
+}
+
+// orForbidden seperti orNotFound, tapi untuk data milik user lain
+func orForbidden(err error, message string) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return forbidden(message)
+	}
+	return err
+}
+
+func unauthorized(message string) *Error {
+	return newError(http.StatusUnauthorized, message)
+}
+
+func tooManyRequests(message string) *Error {
+	return newError(http.StatusTooManyRequests, message)
+}
+
+// retryLater adalah error 429 dengan pesan "coba lagi dalam N detik"
+func retryLater(message string, wait time.Duration) *Error {
+	seconds := max(int((wait+time.Second-1)/time.Second), 1)
+	e := tooManyRequests(fmt.Sprintf("%s, coba lagi dalam %d detik", message, seconds))
+	e.RetryAfter = time.Duration(seconds) * time.Second
+	return e
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"go-evermos/internal/buildinfo"
+	"log"
+	"os"
+)
+
+// Pinger memeriksa koneksi database (dipenuhi *sql.DB)
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// SchemaVersioner membaca versi skema database (dipenuhi *migrate.Migrator)
+type SchemaVersioner interface {
+	Version(ctx context.Context) (int64, error)
+	Latest() int64
+}
+
+// Readiness adalah hasil pengecekan kesiapan. Checks berisi "ok" atau
+// alasan singkat untuk setiap komponen.
+type Readiness struct {
+	Ready  bool
+	Checks map[string]string
+}
+
+// VersionInfo adalah informasi build beserta versi skema database
+type VersionInfo struct {
+	buildinfo.Info
+	SchemaVersion int64 `json:"schema_version"`
+	SchemaLatest  int64 `json:"schema_latest"`
+}
+
+type HealthService struct {
+	db        Pinger
+	schema    SchemaVersioner
+	uploadDir string
+}
+
+func NewHealthService(db Pinger, schema SchemaVersioner, uploadDir string) *HealthService {
+	return &HealthService{db: db, schema: schema, uploadDir: uploadDir}
+}
+
+// Ready memeriksa semua yang dibutuhkan untuk melayani request: database bisa
+// diakses, semua migrasi sudah diterapkan, dan folder upload bisa ditulis.
+// Detail error hanya ditulis ke log, bukan ke response.
+func (s *HealthService) Ready(ctx context.Context) Readiness {
+	r := Readiness{Ready: true, Checks: map[string]string{}}
+	check := func(name string, err error, message string) {
+		if err == nil {
+			r.Checks[name] = "ok"
+			return
+		}
+		log.Printf("readiness %s: %v", name, err)
+		r.Ready = false
+		r.Checks[name] = message
+	}
+
+	dbErr := s.db.PingContext(ctx)
+	check("database", dbErr, "database tidak bisa diakses")
+
+	if dbErr == nil {
+		version, err := s.schema.Version(ctx)
+		if err == nil && version < s.schema.Latest() {
+			err = fmt.Errorf("skema versi %d, terbaru %d", version, s.schema.Latest())
+		}
+		check("migrations", err, "migrasi database belum lengkap")
+	} else {
+		r.Checks["migrations"] = "tidak diperiksa"
+	}
+
+	check("uploads", writable(s.uploadDir), "folder upload tidak bisa ditulis")
+	return r
+}
+
+// Version mengembalikan informasi build dan versi skema. Versi skema 0 jika
+// database tidak bisa dibaca.
+func (s *HealthService) Version(ctx context.Context) VersionInfo {
+	version, err := s.schema.Version(ctx)
+	if err != nil {
+		log.Println("Gagal membaca versi skema:", err)
+	}
+	return VersionInfo{
+		Info:          buildinfo.Get(),
+		SchemaVersion: version,
+		SchemaLatest:  s.schema.Latest(),
+	}
+}
+
+// writable memastikan dir ada dan bisa ditulis dengan membuat file sementara
+func writable(dir string) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, ".readyz-*")
+	if err != nil {
+		return err
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-evermos/internal/entities"
+	"go-evermos/internal/mail"
+	"go-evermos/internal/repository"
+	"go-evermos/internal/repository/memory"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestRepos membuat repository memori kosong
+func newTestRepos() *repository.Repositories {
+	return memory.New()
+}
+
+// allowAll adalah AccountPolicy yang mengizinkan semua aksi
+type allowAll struct{}
+
+func (allowAll) Allow(uint, Action) error { return nil }
+
+// outbox adalah Mailer yang menyimpan email terkirim di memori
+type outbox struct {
+	mu   sync.Mutex
+	msgs []mail.Message
+}
+
+func (o *outbox) Send(ctx context.Context, msg mail.Message) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.msgs = append(o.msgs, msg)
+	return nil
+}
+
+func (o *outbox) sent() []mail.Message {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]mail.Message(nil), o.msgs...)
+}
+
+// seedUser membuat user beserta tokonya
+func seedUser(t *testing.T, repos *repository.Repositories, nama string) (*entities.User, *entities.Store) {
+	t.Helper()
+
+	user := &entities.User{
+		Nama:         nama,
+		KataSandi:    "hash",
+		Notelp:       "+62811" + nama,
+		TanggalLahir: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		Email:        nama + "@x.com",
+	}
+	if err := repos.Users.Create(user); err != nil {
+		t.Fatal(err)
+	}
+	store := &entities.Store{IDUser: user.ID}
+	if err := repos.Stores.Create(store); err != nil {
+		t.Fatal(err)
+	}
+	return user, store
+}
+
+// seedAddress membuat alamat pengiriman milik user
+func seedAddress(t *testing.T, repos *repository.Repositories, userID uint) uint {
+	t.Helper()
+
+	alamat := &entities.Address{IDUser: userID, JudulAlamat: "Rumah", NamaPenerima: "Penerima", NoTelp: "+628123456789", DetailAlamat: "Jl. Contoh 1"}
+	if err := repos.Addresses.Create(alamat); err != nil {
+		t.Fatal(err)
+	}
+	return alamat.ID
+}
+
+// seedProduct membuat produk di toko dengan harga reseller 700 dan harga
+// konsumen 1000
+func seedProduct(t *testing.T, repos *repository.Repositories, storeID uint, stok int, status string) *entities.Product {
+	t.Helper()
+
+	produk := &entities.Product{
+		NamaProduk:    "Produk",
+		Slug:          "produk",
+		HargaReseller: "700",
+		HargaKonsumen: "1000",
+		IDToko:        storeID,
+		Stok:          stok,
+		Status:        status,
+	}
+	if err := repos.Products.Create(produk); err != nil {
+		t.Fatal(err)
+	}
+	return produk
+}
+
+// assertStatus memastikan err adalah *Error dengan status HTTP tertentu
+func assertStatus(t *testing.T, err error, status int) {
+	t.Helper()
+
+	var e *Error
+	if !errors.As(err, &e) {
+		t.Fatalf("error %v, seharusnya *Error dengan status %d", err, status)
+	}
+	if e.Status != status {
+		t.Fatalf("status %d (%s), seharusnya %d", e.Status, e.Message, status)
+	}
+}
@@ -0,0 +1,437 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gosimple/slug"
+	"github.com/xuri/excelize/v2"
+)
+
+// Kolom file import/export katalog. Urutan ini juga dipakai saat export
+// supaya hasil export bisa langsung di-import ulang.
+var productSheetHeader = []string{
+	"nama_produk",
+	"harga_reseller",
+	"harga_konsumen",
+	"stok",
+	"kategori",
+	"deskripsi",
+	"foto",
+	"status",
+}
+
+// Kolom yang wajib ada di header file import
+var requiredImportColumns = []string{"nama_produk", "harga_reseller", "harga_konsumen", "stok", "kategori"}
+
+// Batas jumlah baris per file import
+const maxImportRows = 5000
+
+// Pemisah beberapa foto dalam satu sel kolom foto
+const fotoSeparator = "|"
+
+type ImportService struct {
+	repos *repository.Repositories
+	// running menghitung job yang sedang diproses di background
+	running sync.WaitGroup
+}
+
+func NewImportService(repos *repository.Repositories) *ImportService {
+	return &ImportService{repos: repos}
+}
+
+// Start membuat job import untuk toko user lalu memproses file di background
+func (s *ImportService) Start(userID uint, filename string, data []byte, dryRun bool) (*entities.ImportJob, error) {
+	store, err := ownedStore(s.repos, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	format := SheetFormat(filename)
+	if format == "" {
+		return nil, badRequest("Format file harus csv atau xlsx")
+	}
+
+	job := entities.ImportJob{
+		IDToko:   store.ID,
+		IDUser:   userID,
+		NamaFile: filename,
+		Format:   format,
+		DryRun:   dryRun,
+		Status:   entities.ImportStatusPending,
+	}
+	if err := s.repos.ImportJobs.Create(&job); err != nil {
+		return nil, err
+	}
+
+	s.running.Add(1)
+	go func() {
+		defer s.running.Done()
+		s.process(job, data)
+	}()
+
+	return &job, nil
+}
+
+// Wait menunggu semua job import yang sedang berjalan selesai. Dipanggil saat
+// shutdown supaya job tidak terpotong di tengah.
+func (s *ImportService) Wait() {
+	s.running.Wait()
+}
+
+// Get menampilkan status dan error per baris dari job import milik toko user
+func (s *ImportService) Get(userID, id uint) (*entities.ImportJob, error) {
+	store, err := ownedStore(s.repos, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.repos.ImportJobs.FindForStore(id, store.ID)
+	if err != nil {
+		return nil, orNotFound(err, "Job import tidak ditemukan")
+	}
+	return job, nil
+}
+
+// Export membuat file katalog toko dalam format yang sama dengan import.
+// Mengembalikan isi file dan ID toko (untuk nama file).
+func (s *ImportService) Export(userID uint, format string) ([]byte, uint, error) {
+	store, err := ownedStore(s.repos, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if format != "csv" && format != "xlsx" {
+		return nil, 0, badRequest("Format harus csv atau xlsx")
+	}
+
+	products, err := s.repos.Products.ListByStore(store.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows := [][]string{productSheetHeader}
+	for _, produk := range products {
+		var fotos []string
+		for _, foto := range produk.ProductPicture {
+			fotos = append(fotos, foto.Url)
+		}
+		deskripsi := ""
+		if produk.Deskripsi != nil {
+			deskripsi = *produk.Deskripsi
+		}
+		rows = append(rows, []string{
+			produk.NamaProduk,
+			produk.HargaReseller,
+			produk.HargaKonsumen,
+			strconv.Itoa(produk.Stok),
+			strconv.FormatUint(uint64(produk.IDCategory), 10),
+			deskripsi,
+			strings.Join(fotos, fotoSeparator),
+			produk.Status,
+		})
+	}
+
+	data, err := writeProductSheet(format, rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return data, store.ID, nil
+}
+
+// process memvalidasi setiap baris dan (jika bukan dry run) menyimpan produk
+func (s *ImportService) process(job entities.ImportJob, data []byte) {
+	job.Status = entities.ImportStatusProcessing
+	if err := s.repos.ImportJobs.Save(&job); err != nil {
+		log.Println("Gagal update job import:", err)
+	}
+
+	records, err := readProductSheet(job.Format, data)
+	if err != nil {
+		s.fail(&job, "File tidak bisa dibaca: "+err.Error())
+		return
+	}
+	if len(records) == 0 {
+		s.fail(&job, "File kosong")
+		return
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range requiredImportColumns {
+		if _, ok := columns[name]; !ok {
+			s.fail(&job, "Kolom "+name+" tidak ditemukan di header")
+			return
+		}
+	}
+
+	records = records[1:]
+	if len(records) > maxImportRows {
+		s.fail(&job, fmt.Sprintf("Maksimal %d baris per file", maxImportRows))
+		return
+	}
+
+	categories := loadImportCategories(s.repos)
+	rowErrors := []entities.ImportRowError{}
+	var total, sukses, gagal int
+
+	for i, record := range records {
+		// baris 1 adalah header
+		baris := i + 2
+		if isEmptyRecord(record) {
+			continue
+		}
+		total++
+
+		produk, fotos, errs := parseImportRow(baris, columns, record, categories)
+		if len(errs) > 0 {
+			rowErrors = append(rowErrors, errs...)
+			gagal++
+			continue
+		}
+
+		if job.DryRun {
+			sukses++
+			continue
+		}
+
+		produk.IDToko = job.IDToko
+		err := s.repos.Transaction(func(tx *repository.Repositories) error {
+			if err := tx.Products.Create(&produk); err != nil {
+				return err
+			}
+			if err := recordStockMovement(tx, produk.ID, entities.StockMovementImport, 0, produk.Stok, nil, &job.IDUser, "Import "+job.NamaFile); err != nil {
+				return err
+			}
+			for _, url := range fotos {
+				foto := entities.ProductPicture{IDProduk: produk.ID, Url: url}
+				if err := tx.Products.CreatePicture(&foto); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			rowErrors = append(rowErrors, entities.ImportRowError{Baris: baris, Pesan: "Gagal simpan produk"})
+			gagal++
+			continue
+		}
+		sukses++
+	}
+
+	job.Status = entities.ImportStatusCompleted
+	job.TotalBaris = total
+	job.BarisSukses = sukses
+	job.BarisGagal = gagal
+	job.Errors = rowErrors
+	if err := s.repos.ImportJobs.Save(&job); err != nil {
+		log.Println("Gagal update job import:", err)
+	}
+}
+
+func (s *ImportService) fail(job *entities.ImportJob, pesan string) {
+	job.Status = entities.ImportStatusFailed
+	job.Pesan = &pesan
+	if err := s.repos.ImportJobs.Save(job); err != nil {
+		log.Println("Gagal update job import:", err)
+	}
+}
+
+// importCategories menampung kategori berdasarkan ID dan nama (lowercase)
+type importCategories struct {
+	byID   map[uint]bool
+	byName map[string]uint
+}
+
+func loadImportCategories(repos *repository.Repositories) importCategories {
+	categories, _ := repos.Categories.All()
+
+	result := importCategories{byID: map[uint]bool{}, byName: map[string]uint{}}
+	for _, cat := range categories {
+		result.byID[cat.ID] = true
+		result.byName[strings.ToLower(cat.NamaCategory)] = cat.ID
+	}
+	return result
+}
+
+// parseImportRow mengubah satu baris menjadi produk beserta fotonya,
+// atau mengembalikan daftar error
+func parseImportRow(baris int, columns map[string]int, record []string, categories importCategories) (entities.Product, []string, []entities.ImportRowError) {
+	var errs []entities.ImportRowError
+	get := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	addErr := func(kolom, pesan string) {
+		errs = append(errs, entities.ImportRowError{Baris: baris, Kolom: kolom, Pesan: pesan})
+	}
+
+	namaProduk := get("nama_produk")
+	if namaProduk == "" {
+		addErr("nama_produk", "Nama produk wajib diisi")
+	}
+
+	hargaReseller := get("harga_reseller")
+	if n, err := strconv.Atoi(hargaReseller); err != nil || n < 0 {
+		addErr("harga_reseller", "Harga reseller harus angka >= 0")
+	}
+
+	hargaKonsumen := get("harga_konsumen")
+	if n, err := strconv.Atoi(hargaKonsumen); err != nil || n < 0 {
+		addErr("harga_konsumen", "Harga konsumen harus angka >= 0")
+	}
+
+	stok, err := strconv.Atoi(get("stok"))
+	if err != nil || stok < 0 {
+		addErr("stok", "Stok harus angka >= 0")
+	}
+
+	var idCategory uint
+	kategori := get("kategori")
+	if id, err := strconv.ParseUint(kategori, 10, 64); err == nil && categories.byID[uint(id)] {
+		idCategory = uint(id)
+	} else if id, ok := categories.byName[strings.ToLower(kategori)]; ok && kategori != "" {
+		idCategory = id
+	} else {
+		addErr("kategori", "Kategori tidak ditemukan")
+	}
+
+	var fotos []string
+	for _, foto := range strings.Split(get("foto"), fotoSeparator) {
+		foto = strings.TrimSpace(foto)
+		if foto == "" {
+			continue
+		}
+		url, ok := resolveImportFoto(foto)
+		if !ok {
+			addErr("foto", "Foto "+foto+" tidak ditemukan")
+			continue
+		}
+		fotos = append(fotos, url)
+	}
+
+	status := strings.ToLower(get("status"))
+	if status == "" {
+		status = entities.ProductStatusActive
+	}
+	if !IsOwnerProductStatus(status) {
+		addErr("status", "Status harus draft, active atau archived")
+	}
+
+	deskripsi := get("deskripsi")
+	produk := entities.Product{
+		NamaProduk:    namaProduk,
+		Slug:          slug.Make(namaProduk),
+		HargaReseller: hargaReseller,
+		HargaKonsumen: hargaKonsumen,
+		Stok:          stok,
+		Deskripsi:     &deskripsi,
+		IDCategory:    idCategory,
+		Status:        status,
+	}
+	return produk, fotos, errs
+}
+
+// resolveImportFoto menerima URL http(s) apa adanya, atau nama file yang
+// sudah ada di folder uploads
+func resolveImportFoto(foto string) (string, bool) {
+	if strings.HasPrefix(foto, "http://") || strings.HasPrefix(foto, "https://") {
+		return foto, true
+	}
+
+	name := strings.TrimPrefix(strings.TrimPrefix(filepath.ToSlash(foto), "./"), "uploads/")
+	if name != filepath.Base(name) {
+		return "", false
+	}
+	path := filepath.Join(UploadDir, name)
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		return "", false
+	}
+	return path, true
+}
+
+// SheetFormat menentukan format import dari ekstensi file ("" jika tidak didukung)
+func SheetFormat(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return "csv"
+	case ".xlsx":
+		return "xlsx"
+	}
+	return ""
+}
+
+func isEmptyRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// readProductSheet membaca semua baris (termasuk header) dari file CSV/XLSX
+func readProductSheet(format string, data []byte) ([][]string, error) {
+	if format == "xlsx" {
+		f, err := excelize.OpenReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		sheets := f.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, nil
+		}
+		return f.GetRows(sheets[0])
+	}
+
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	r.FieldsPerRecord = -1
+	return r.ReadAll()
+}
+
+func writeProductSheet(format string, rows [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if format == "xlsx" {
+		f := excelize.NewFile()
+		defer f.Close()
+
+		sheet := f.GetSheetName(0)
+		for i, row := range rows {
+			values := make([]interface{}, len(row))
+			for j, v := range row {
+				values[j] = v
+			}
+			cell, _ := excelize.CoordinatesToCellName(1, i+1)
+			if err := f.SetSheetRow(sheet, cell, &values); err != nil {
+				return nil, err
+			}
+		}
+		if err := f.Write(&buf); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
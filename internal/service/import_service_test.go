@@ -0,0 +1,125 @@
+package service
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// runImport menjalankan import sampai selesai lalu mengembalikan job-nya
+func runImport(t *testing.T, s *ImportService, userID uint, filename, data string, dryRun bool) *entities.ImportJob {
+	t.Helper()
+
+	job, err := s.Start(userID, filename, []byte(data), dryRun)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Wait()
+
+	job, err = s.Get(userID, job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return job
+}
+
+func seedImportCategory(t *testing.T, repos *repository.Repositories) {
+	t.Helper()
+
+	if err := repos.Categories.Create(&entities.Category{NamaCategory: "Fashion"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+const importHeader = "nama_produk,harga_reseller,harga_konsumen,stok,kategori,status\n"
+
+func TestImportValidatesRows(t *testing.T) {
+	repos := newTestRepos()
+	seller, store := seedUser(t, repos, "penjual")
+	seedImportCategory(t, repos)
+	s := NewImportService(repos)
+
+	job := runImport(t, s, seller.ID, "katalog.csv", importHeader+
+		"Kaos,700,1000,5,fashion,\n"+
+		",abc,1000,-1,Fashion,active\n"+
+		",,,,,\n"+
+		"Topi,700,-5,2,Elektronik,dijual\n", false)
+
+	if job.Status != entities.ImportStatusCompleted {
+		t.Fatalf("status job %s, seharusnya %s", job.Status, entities.ImportStatusCompleted)
+	}
+	if job.TotalBaris != 3 || job.BarisSukses != 1 || job.BarisGagal != 2 {
+		t.Errorf("total/sukses/gagal %d/%d/%d, seharusnya 3/1/2", job.TotalBaris, job.BarisSukses, job.BarisGagal)
+	}
+
+	// baris kosong dilewati tapi nomor baris tetap mengikuti file
+	want := []entities.ImportRowError{
+		{Baris: 3, Kolom: "nama_produk", Pesan: "Nama produk wajib diisi"},
+		{Baris: 3, Kolom: "harga_reseller", Pesan: "Harga reseller harus angka >= 0"},
+		{Baris: 3, Kolom: "stok", Pesan: "Stok harus angka >= 0"},
+		{Baris: 5, Kolom: "harga_konsumen", Pesan: "Harga konsumen harus angka >= 0"},
+		{Baris: 5, Kolom: "kategori", Pesan: "Kategori tidak ditemukan"},
+		{Baris: 5, Kolom: "status", Pesan: "Status harus draft, active atau archived"},
+	}
+	if !slices.Equal(job.Errors, want) {
+		t.Errorf("error baris %+v, seharusnya %+v", job.Errors, want)
+	}
+
+	products, err := repos.Products.ListByStore(store.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(products) != 1 || products[0].NamaProduk != "Kaos" || products[0].Stok != 5 || products[0].Status != entities.ProductStatusActive {
+		t.Errorf("produk tersimpan %+v, seharusnya hanya Kaos aktif dengan stok 5", products)
+	}
+}
+
+func TestImportDryRunSavesNothing(t *testing.T) {
+	repos := newTestRepos()
+	seller, store := seedUser(t, repos, "penjual")
+	seedImportCategory(t, repos)
+	s := NewImportService(repos)
+
+	job := runImport(t, s, seller.ID, "katalog.csv", importHeader+
+		"Kaos,700,1000,5,Fashion,draft\n"+
+		"Topi,700,1000,x,Fashion,\n", true)
+
+	if job.Status != entities.ImportStatusCompleted || !job.DryRun {
+		t.Fatalf("status job %s (dry run %v)", job.Status, job.DryRun)
+	}
+	if job.BarisSukses != 1 || job.BarisGagal != 1 || len(job.Errors) != 1 {
+		t.Errorf("sukses/gagal %d/%d dengan error %+v, seharusnya 1/1", job.BarisSukses, job.BarisGagal, job.Errors)
+	}
+
+	products, err := repos.Products.ListByStore(store.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(products) != 0 {
+		t.Errorf("dry run menyimpan %d produk", len(products))
+	}
+}
+
+func TestImportRejectsInvalidFile(t *testing.T) {
+	repos := newTestRepos()
+	seller, _ := seedUser(t, repos, "penjual")
+	s := NewImportService(repos)
+
+	cases := map[string]string{
+		"nama_produk,harga_reseller,harga_konsumen,kategori\nKaos,700,1000,Fashion\n": "Kolom stok tidak ditemukan di header",
+		"": "File kosong",
+		importHeader + strings.Repeat("Kaos,700,1000,5,Fashion,\n", maxImportRows+1): "Maksimal 5000 baris per file",
+	}
+	for data, want := range cases {
+		job := runImport(t, s, seller.ID, "katalog.csv", data, true)
+		if job.Status != entities.ImportStatusFailed || job.Pesan == nil || *job.Pesan != want {
+			t.Errorf("job %s dengan pesan %v, seharusnya gagal: %s", job.Status, job.Pesan, want)
+		}
+	}
+
+	_, err := s.Start(seller.ID, "katalog.pdf", []byte(importHeader), true)
+	assertStatus(t, err, http.StatusBadRequest)
+}
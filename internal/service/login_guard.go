@@ -0,0 +1,159 @@
+package service
+
+import (
+	"errors"
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"log"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// accountFreeAttempts dan ipFreeAttempts adalah jumlah login gagal
+	// sebelum backoff mulai berlaku
+	accountFreeAttempts = 3
+	ipFreeAttempts      = 10
+	// loginBackoffBase adalah jeda setelah gagal pertama melewati batas
+	// bebas; jeda berikutnya dua kali lipat sampai Lockout
+	loginBackoffBase = time.Second
+)
+
+// errInvalidLogin sengaja sama untuk akun tidak terdaftar dan password
+// salah supaya login tidak bisa dipakai menebak email/no telp
+var errInvalidLogin = unauthorized("Email/no telepon atau password salah")
+
+// dummyPasswordHash dibandingkan saat email tidak terdaftar supaya lama
+// respons sama dengan password salah
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
+// LoginLimits mengatur proteksi brute force login
+type LoginLimits struct {
+	// MaxAttempts adalah jumlah login gagal per akun sebelum akun dikunci
+	MaxAttempts int
+	// IPMaxAttempts adalah jumlah login gagal per IP (semua akun) sebelum
+	// IP dikunci
+	IPMaxAttempts int
+	// Lockout adalah lama penguncian sekaligus rentang waktu login gagal
+	// dihitung
+	Lockout time.Duration
+}
+
+// LoginClient adalah informasi client yang dicatat di setiap percobaan login
+type LoginClient struct {
+	IP        string
+	UserAgent string
+}
+
+// findLoginUser mencari user berdasarkan email (jika mengandung @) atau no
+// telp. Akun yang tidak ditemukan mengembalikan nil tanpa error.
+func (s *AuthService) findLoginUser(identifier string) (*entities.User, error) {
+	identifier = strings.TrimSpace(identifier)
+
+	var (
+		user *entities.User
+		err  error
+	)
+	if strings.Contains(identifier, "@") {
+		user, err = s.repos.Users.FindByEmail(identifier)
+	} else {
+		phone, perr := normalizePhone(identifier)
+		if perr != nil {
+			return nil, nil
+		}
+		user, err = s.repos.Users.FindByPhone(phone)
+	}
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil
+	}
+	return user, err
+}
+
+// loginKey adalah kunci penghitung login gagal per akun. Untuk akun yang
+// dikenal dipakai email akun, sehingga login lewat email dan no telp berbagi
+// batas yang sama.
+func loginKey(identifier string, user *entities.User) string {
+	if user != nil {
+		return normalizeLoginEmail(user.Email)
+	}
+	if phone, err := normalizePhone(identifier); err == nil {
+		return phone
+	}
+	return normalizeLoginEmail(identifier)
+}
+
+// loginWait mengembalikan sisa waktu sebelum email/IP boleh mencoba login
+// lagi (0 jika boleh sekarang)
+func (s *AuthService) loginWait(repos *repository.Repositories, email, ip string, now time.Time) (time.Duration, error) {
+	since := now.Add(-s.limits.Lockout)
+
+	account, err := repos.LoginAttempts.EmailFailures(email, since)
+	if err != nil {
+		return 0, err
+	}
+	byIP, err := repos.LoginAttempts.IPFailures(ip, since)
+	if err != nil {
+		return 0, err
+	}
+
+	wait := max(
+		s.backoff(account, accountFreeAttempts, s.limits.MaxAttempts, now),
+		s.backoff(byIP, ipFreeAttempts, s.limits.IPMaxAttempts, now),
+	)
+	return wait, nil
+}
+
+// backoff menghitung jeda setelah login gagal terakhir: tidak ada jeda
+// sampai free kali gagal, lalu naik dua kali lipat, dan Lockout penuh
+// setelah limit kali gagal
+func (s *AuthService) backoff(f repository.LoginFailures, free, limit int, now time.Time) time.Duration {
+	if f.Count < int64(free) && f.Count < int64(limit) {
+		return 0
+	}
+
+	delay := s.limits.Lockout
+	if f.Count < int64(limit) {
+		if shift := f.Count - int64(free); shift < 30 {
+			delay = min(loginBackoffBase<<shift, s.limits.Lockout)
+		}
+	}
+	return max(f.Last.Add(delay).Sub(now), 0)
+}
+
+// recordLogin mencatat percobaan login. Kegagalan mencatat hanya di-log
+// supaya login tetap bisa dipakai.
+func (s *AuthService) recordLogin(email string, user *entities.User, client LoginClient, outcome string) {
+	attempt := entities.LoginAttempt{
+		Email:     email,
+		IP:        client.IP,
+		UserAgent: truncate(client.UserAgent, 255),
+		Outcome:   outcome,
+	}
+	if user != nil {
+		attempt.IDUser = &user.ID
+	}
+	if err := s.repos.LoginAttempts.Create(&attempt); err != nil {
+		log.Printf("Gagal mencatat percobaan login %s dari %s: %v", email, client.IP, err)
+	}
+}
+
+// LoginAttempts menampilkan riwayat percobaan login untuk audit admin
+func (s *AuthService) LoginAttempts(filter repository.LoginAttemptFilter) ([]entities.LoginAttempt, int64, error) {
+	filter.Email = normalizeLoginEmail(filter.Email)
+	return s.repos.LoginAttempts.List(filter)
+}
+
+// normalizeLoginEmail menyamakan penulisan email supaya penghitung login
+// gagal tidak bisa dihindari dengan huruf besar/spasi
+func normalizeLoginEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return strings.ToValidUTF8(s[:n], "")
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"errors"
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// seedFailures mencatat login gagal untuk email dari IP pada waktu tertentu
+func seedFailures(t *testing.T, repos *repository.Repositories, email, ip string, n int, at time.Time) {
+	t.Helper()
+
+	for range n {
+		attempt := &entities.LoginAttempt{Model: entities.Model{CreatedAt: at}, Email: email, IP: ip, Outcome: entities.LoginFailed}
+		if err := repos.LoginAttempts.Create(attempt); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// assertRetryAfter memastikan err adalah 429 dengan Retry-After antara min dan max
+func assertRetryAfter(t *testing.T, err error, min, max time.Duration) {
+	t.Helper()
+
+	assertStatus(t, err, http.StatusTooManyRequests)
+	var e *Error
+	errors.As(err, &e)
+	if e.RetryAfter < min || e.RetryAfter > max {
+		t.Errorf("Retry-After %v, seharusnya antara %v dan %v", e.RetryAfter, min, max)
+	}
+}
+
+func TestLoginBackoffAfterFreeAttempts(t *testing.T) {
+	repos := newTestRepos()
+	user := seedLoginUser(t, repos, "user")
+	s := newTestAuth(repos, LoginLimits{MaxAttempts: 10, IPMaxAttempts: 100, Lockout: time.Hour})
+
+	for i := range accountFreeAttempts {
+		_, err := s.Login(user.Email, "salah", testClient)
+		assertStatus(t, err, http.StatusUnauthorized)
+		if t.Failed() {
+			t.Fatalf("percobaan ke-%d", i+1)
+		}
+	}
+
+	// setelah batas bebas, login (walau password benar) harus menunggu
+	_, err := s.Login(user.Email, testPassword, testClient)
+	assertRetryAfter(t, err, time.Second, time.Second)
+
+	// percobaan yang ditolak karena backoff tidak menambah hitungan gagal
+	failures, _ := repos.LoginAttempts.EmailFailures(user.Email, time.Now().Add(-time.Hour))
+	if failures.Count != int64(accountFreeAttempts) {
+		t.Errorf("%d login gagal tercatat, seharusnya %d", failures.Count, accountFreeAttempts)
+	}
+}
+
+func TestLoginBackoffDoubles(t *testing.T) {
+	cases := []struct {
+		failures int
+		ago      time.Duration
+		allowed  bool
+	}{
+		{accountFreeAttempts + 1, time.Second + 500*time.Millisecond, false}, // jeda 2 detik
+		{accountFreeAttempts + 1, 3 * time.Second, true},
+		{accountFreeAttempts + 2, 3 * time.Second, false}, // jeda 4 detik
+		{accountFreeAttempts + 2, 5 * time.Second, true},
+	}
+	for _, tc := range cases {
+		repos := newTestRepos()
+		user := seedLoginUser(t, repos, "user")
+		s := newTestAuth(repos, LoginLimits{MaxAttempts: 10, IPMaxAttempts: 100, Lockout: time.Hour})
+		seedFailures(t, repos, user.Email, testClient.IP, tc.failures, time.Now().Add(-tc.ago))
+
+		_, err := s.Login(user.Email, testPassword, testClient)
+		if tc.allowed && err != nil {
+			t.Errorf("%d gagal %v lalu: %v, seharusnya boleh login", tc.failures, tc.ago, err)
+		}
+		if !tc.allowed {
+			assertStatus(t, err, http.StatusTooManyRequests)
+		}
+	}
+}
+
+func TestLoginLockout(t *testing.T) {
+	repos := newTestRepos()
+	user := seedLoginUser(t, repos, "user")
+	limits := LoginLimits{MaxAttempts: 5, IPMaxAttempts: 100, Lockout: time.Hour}
+	s := newTestAuth(repos, limits)
+	seedFailures(t, repos, user.Email, "10.0.0.9", limits.MaxAttempts, time.Now().Add(-time.Minute))
+
+	// akun dikunci penuh, walau login dari IP lain
+	_, err := s.Login(user.Email, testPassword, testClient)
+	assertRetryAfter(t, err, 58*time.Minute, time.Hour)
+
+	// kunci berakhir setelah Lockout
+	repos = newTestRepos()
+	user = seedLoginUser(t, repos, "user")
+	s = newTestAuth(repos, limits)
+	seedFailures(t, repos, user.Email, "10.0.0.9", limits.MaxAttempts, time.Now().Add(-limits.Lockout-time.Minute))
+	if _, err := s.Login(user.Email, testPassword, testClient); err != nil {
+		t.Errorf("akun masih terkunci setelah Lockout: %v", err)
+	}
+}
+
+func TestLoginIPLockout(t *testing.T) {
+	repos := newTestRepos()
+	user := seedLoginUser(t, repos, "user")
+	limits := LoginLimits{MaxAttempts: 100, IPMaxAttempts: 4, Lockout: time.Hour}
+	s := newTestAuth(repos, limits)
+	seedFailures(t, repos, "lain@x.com", testClient.IP, limits.IPMaxAttempts, time.Now().Add(-time.Minute))
+
+	_, err := s.Login(user.Email, testPassword, testClient)
+	assertRetryAfter(t, err, 58*time.Minute, time.Hour)
+
+	if _, err := s.Login(user.Email, testPassword, LoginClient{IP: "10.0.0.2"}); err != nil {
+		t.Errorf("login dari IP lain ikut dikunci: %v", err)
+	}
+}
+
+func TestLoginSuccessResetsAccountCounter(t *testing.T) {
+	repos := newTestRepos()
+	user := seedLoginUser(t, repos, "user")
+	s := newTestAuth(repos, LoginLimits{MaxAttempts: 5, IPMaxAttempts: 100, Lockout: time.Hour})
+	seedFailures(t, repos, user.Email, testClient.IP, accountFreeAttempts+1, time.Now().Add(-time.Minute))
+
+	login(t, s, user)
+
+	// hitungan gagal mulai dari 0 lagi: batas bebas berlaku penuh
+	for range accountFreeAttempts {
+		_, err := s.Login(user.Email, "salah", testClient)
+		assertStatus(t, err, http.StatusUnauthorized)
+	}
+	_, err := s.Login(user.Email, testPassword, testClient)
+	assertStatus(t, err, http.StatusTooManyRequests)
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"time"
+)
+
+type NotificationService struct {
+	repos *repository.Repositories
+}
+
+func NewNotificationService(repos *repository.Repositories) *NotificationService {
+	return &NotificationService{repos: repos}
+}
+
+// List mengambil notifikasi milik user
+func (s *NotificationService) List(userID uint, filter repository.NotificationFilter) ([]entities.Notification, error) {
+	return s.repos.Notifications.ListForUser(userID, filter)
+}
+
+// MarkRead menandai notifikasi sudah dibaca
+func (s *NotificationService) MarkRead(userID, id uint) error {
+	return s.repos.Notifications.MarkRead(id, userID, time.Now())
+}
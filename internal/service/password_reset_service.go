@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-evermos/internal/entities"
+	"go-evermos/internal/mail"
+	"go-evermos/internal/repository"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// mailTimeout membatasi lama pengiriman satu email di background
+const mailTimeout = 30 * time.Second
+
+const (
+	// resetRequestWindow adalah rentang waktu permintaan reset dihitung
+	resetRequestWindow = time.Hour
+	// resetEmailLimit dan resetIPLimit adalah jumlah permintaan reset per
+	// email dan per IP dalam resetRequestWindow
+	resetEmailLimit = 3
+	resetIPLimit    = 10
+)
+
+type PasswordResetService struct {
+	repos  *repository.Repositories
+	mailer mail.Mailer
+	ttl    time.Duration
+	// resetURL adalah halaman reset password di frontend
+	resetURL string
+	// sending menghitung email yang sedang dikirim di background
+	sending sync.WaitGroup
+}
+
+func NewPasswordResetService(repos *repository.Repositories, mailer mail.Mailer, ttl time.Duration, resetURL string) *PasswordResetService {
+	return &PasswordResetService{repos: repos, mailer: mailer, ttl: ttl, resetURL: resetURL}
+}
+
+// Request mencatat permintaan reset lalu membuat token dan mengirimkannya ke
+// email user di background. Di jalur request, email terdaftar maupun tidak
+// melakukan pekerjaan yang sama (cek batas dan catat permintaan), supaya
+// respons dan lamanya tidak membocorkan email mana yang terdaftar.
+// Permintaan dibatasi per email dan per IP supaya inbox korban tidak bisa
+// dibanjiri.
+func (s *PasswordResetService) Request(email, ip string) error {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return badRequest("Email wajib diisi")
+	}
+	key := normalizeLoginEmail(email)
+
+	wait, err := s.requestWait(key, ip, time.Now())
+	if err != nil {
+		return err
+	}
+	if wait > 0 {
+		return retryLater("Terlalu banyak permintaan reset password", wait)
+	}
+	if err := s.repos.PasswordResets.CreateRequest(&entities.PasswordResetRequest{Email: key, IP: ip}); err != nil {
+		return err
+	}
+
+	s.sending.Add(1)
+	go func() {
+		defer s.sending.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), mailTimeout)
+		defer cancel()
+		if err := s.send(ctx, email); err != nil {
+			log.Printf("Gagal memproses reset password: %v", err)
+		}
+	}()
+	return nil
+}
+
+// requestWait mengembalikan sisa waktu sebelum email/IP boleh meminta reset
+// lagi (0 jika boleh sekarang)
+func (s *PasswordResetService) requestWait(email, ip string, now time.Time) (time.Duration, error) {
+	since := now.Add(-resetRequestWindow)
+
+	byEmail, err := s.repos.PasswordResets.EmailRequests(email, since)
+	if err != nil {
+		return 0, err
+	}
+	byIP, err := s.repos.PasswordResets.IPRequests(ip, since)
+	if err != nil {
+		return 0, err
+	}
+
+	var wait time.Duration
+	if byEmail.Count >= resetEmailLimit {
+		wait = byEmail.First.Add(resetRequestWindow).Sub(now)
+	}
+	if byIP.Count >= resetIPLimit {
+		wait = max(wait, byIP.First.Add(resetRequestWindow).Sub(now))
+	}
+	return max(wait, 0), nil
+}
+
+// send membuat token reset dan mengirim email jika email terdaftar dan akun
+// tidak diblokir. Hanya link terbaru yang berlaku.
+func (s *PasswordResetService) send(ctx context.Context, email string) error {
+	user, err := s.repos.Users.FindByEmail(email)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if user.BannedAt != nil {
+		return nil
+	}
+
+	raw := newOpaqueToken()
+	now := time.Now()
+	err = s.repos.Transaction(func(tx *repository.Repositories) error {
+		if err := tx.PasswordResets.InvalidateUser(user.ID, now); err != nil {
+			return err
+		}
+		return tx.PasswordResets.Create(&entities.PasswordReset{
+			IDUser:    user.ID,
+			TokenHash: hashToken(raw),
+			ExpiresAt: now.Add(s.ttl),
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("user %d: %w", user.ID, err)
+	}
+
+	msg := mail.Message{
+		To:      user.Email,
+		Subject: "Reset password akun Evermos",
+		Body: fmt.Sprintf("Halo %s,\n\n"+
+			"Kami menerima permintaan reset password untuk akun kamu. Buka link berikut untuk membuat password baru:\n\n"+
+			"%s\n\n"+
+			"Link berlaku %d menit dan hanya bisa dipakai sekali. Abaikan email ini jika kamu tidak meminta reset password.\n",
+			user.Nama, s.link(raw), int(s.ttl.Minutes())),
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		return fmt.Errorf("mengirim email ke user %d: %w", user.ID, err)
+	}
+	return nil
+}
+
+// Reset mengganti password dengan token dari email. Token langsung hangus
+// dan semua sesi user dicabut.
+func (s *PasswordResetService) Reset(raw, newPassword string) error {
+	if newPassword == "" {
+		return badRequest("Password baru wajib diisi")
+	}
+	invalid := badRequest("Token reset tidak valid atau sudah kedaluwarsa")
+
+	now := time.Now()
+	return s.repos.Transaction(func(tx *repository.Repositories) error {
+		reset, err := tx.PasswordResets.FindByHashForUpdate(hashToken(raw))
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return invalid
+			}
+			return err
+		}
+		if reset.UsedAt != nil || now.After(reset.ExpiresAt) {
+			return invalid
+		}
+
+		user, err := tx.Users.FindByID(reset.IDUser)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return invalid
+			}
+			return err
+		}
+
+		hashed, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+		if err != nil {
+			return err
+		}
+		user.KataSandi = string(hashed)
+
+		if err := tx.PasswordResets.InvalidateUser(user.ID, now); err != nil {
+			return err
+		}
+		return revokeAllSessions(tx, user)
+	})
+}
+
+// Wait menunggu email yang sedang dikirim selesai. Dipanggil saat shutdown.
+func (s *PasswordResetService) Wait() {
+	s.sending.Wait()
+}
+
+func (s *PasswordResetService) link(token string) string {
+	u, err := url.Parse(s.resetURL)
+	if err != nil {
+		return s.resetURL + "?token=" + url.QueryEscape(token)
+	}
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
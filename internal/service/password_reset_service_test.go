@@ -0,0 +1,124 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// requestToken meminta reset untuk email lalu mengambil token dari link di
+// email terakhir
+func requestToken(t *testing.T, s *PasswordResetService, mails *outbox, email string) string {
+	t.Helper()
+
+	if err := s.Request(email, "10.0.0.1"); err != nil {
+		t.Fatal(err)
+	}
+	s.Wait()
+
+	msgs := mails.sent()
+	if len(msgs) == 0 {
+		t.Fatal("email reset tidak terkirim")
+	}
+	for _, line := range strings.Split(msgs[len(msgs)-1].Body, "\n") {
+		if u, err := url.Parse(line); err == nil && u.Query().Has("token") {
+			return u.Query().Get("token")
+		}
+	}
+	t.Fatal("link reset tidak ditemukan di email")
+	return ""
+}
+
+func TestResetRequestLimitsPerEmail(t *testing.T) {
+	repos := newTestRepos()
+	seedUser(t, repos, "terdaftar")
+	mails := &outbox{}
+	s := NewPasswordResetService(repos, mails, time.Hour, "https://contoh.com/reset")
+
+	// email terdaftar dan tidak terdaftar dibatasi dengan cara yang sama
+	for _, email := range []string{"terdaftar@x.com", "tidak-ada@x.com"} {
+		t.Run(email, func(t *testing.T) {
+			for i := range resetEmailLimit {
+				if err := s.Request(email, fmt.Sprintf("10.0.0.%d", i+1)); err != nil {
+					t.Fatalf("permintaan ke-%d: %v", i+1, err)
+				}
+			}
+			err := s.Request(" "+email+" ", "10.0.0.9")
+			assertStatus(t, err, http.StatusTooManyRequests)
+			if e := err.(*Error); e.RetryAfter <= 0 || e.RetryAfter > resetRequestWindow {
+				t.Errorf("RetryAfter %v, seharusnya antara 0 dan %v", e.RetryAfter, resetRequestWindow)
+			}
+		})
+	}
+
+	s.Wait()
+	if n := len(mails.sent()); n != resetEmailLimit {
+		t.Errorf("%d email terkirim, seharusnya %d (hanya ke email terdaftar)", n, resetEmailLimit)
+	}
+}
+
+func TestResetRequestLimitsPerIP(t *testing.T) {
+	repos := newTestRepos()
+	s := NewPasswordResetService(repos, &outbox{}, time.Hour, "")
+
+	for i := range resetIPLimit {
+		if err := s.Request(fmt.Sprintf("user%d@x.com", i), "10.0.0.1"); err != nil {
+			t.Fatalf("permintaan ke-%d: %v", i+1, err)
+		}
+	}
+	assertStatus(t, s.Request("lain@x.com", "10.0.0.1"), http.StatusTooManyRequests)
+	if err := s.Request("lain@x.com", "10.0.0.2"); err != nil {
+		t.Errorf("IP lain ikut dibatasi: %v", err)
+	}
+	s.Wait()
+}
+
+func TestResetTokenSingleUse(t *testing.T) {
+	repos := newTestRepos()
+	user, _ := seedUser(t, repos, "user")
+	mails := &outbox{}
+	s := NewPasswordResetService(repos, mails, time.Hour, "https://contoh.com/reset")
+	token := requestToken(t, s, mails, user.Email)
+
+	if err := s.Reset(token, "baru12345"); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := repos.Users.FindByID(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(updated.KataSandi), []byte("baru12345")) != nil {
+		t.Error("password tidak diganti")
+	}
+
+	assertStatus(t, s.Reset(token, "lain12345"), http.StatusBadRequest)
+}
+
+func TestResetTokenExpires(t *testing.T) {
+	repos := newTestRepos()
+	user, _ := seedUser(t, repos, "user")
+	mails := &outbox{}
+	s := NewPasswordResetService(repos, mails, time.Nanosecond, "https://contoh.com/reset")
+	token := requestToken(t, s, mails, user.Email)
+
+	assertStatus(t, s.Reset(token, "baru12345"), http.StatusBadRequest)
+}
+
+func TestResetTokenInvalidatedByNewerRequest(t *testing.T) {
+	repos := newTestRepos()
+	user, _ := seedUser(t, repos, "user")
+	mails := &outbox{}
+	s := NewPasswordResetService(repos, mails, time.Hour, "https://contoh.com/reset")
+	first := requestToken(t, s, mails, user.Email)
+	second := requestToken(t, s, mails, user.Email)
+
+	assertStatus(t, s.Reset(first, "baru12345"), http.StatusBadRequest)
+	if err := s.Reset(second, "baru12345"); err != nil {
+		t.Errorf("token terbaru ditolak: %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+)
+
+// normalizePhone mengubah no telp ke format E.164. Nomor Indonesia boleh
+// ditulis 08xx, 628xx, +628xx atau 8xx (semuanya menjadi +628xx); spasi,
+// tanda hubung, titik dan kurung diabaikan. Nomor luar negeri harus diawali
+// kode negara (+).
+func normalizePhone(raw string) (string, error) {
+	invalid := badRequest("Format no telepon tidak valid, gunakan 08xx atau +628xx")
+
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(raw) {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '+' && i == 0:
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			if unicode.IsSpace(r) {
+				continue
+			}
+			return "", invalid
+		}
+	}
+	phone := b.String()
+
+	switch {
+	case strings.HasPrefix(phone, "+"):
+	case strings.HasPrefix(phone, "62"):
+		phone = "+" + phone
+	case strings.HasPrefix(phone, "0"):
+		phone = "+62" + phone[1:]
+	case strings.HasPrefix(phone, "8"):
+		phone = "+62" + phone
+	default:
+		return "", invalid
+	}
+
+	// E.164: maksimal 15 digit. Nomor Indonesia setelah +62 berisi 8-12
+	// digit dan tidak diawali 0.
+	digits := phone[1:]
+	if len(digits) < 8 || len(digits) > 15 || strings.HasPrefix(digits, "0") {
+		return "", invalid
+	}
+	if local, ok := strings.CutPrefix(digits, "62"); ok && (len(local) < 8 || len(local) > 12 || local[0] == '0') {
+		return "", invalid
+	}
+	return phone, nil
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	valid := []struct {
+		raw, want string
+	}{
+		{"081234567890", "+6281234567890"},
+		{"6281234567890", "+6281234567890"},
+		{"+6281234567890", "+6281234567890"},
+		{"81234567890", "+6281234567890"},
+		{"0812-3456-7890", "+6281234567890"},
+		{"+62 812 3456 7890", "+6281234567890"},
+		{"(0812) 3456.7890", "+6281234567890"},
+		{"  0812\t3456 7890 ", "+6281234567890"},
+		{"081234567", "+6281234567"},
+		{"+14155552671", "+14155552671"},
+	}
+	for _, tc := range valid {
+		got, err := normalizePhone(tc.raw)
+		if err != nil {
+			t.Errorf("normalizePhone(%q): %v", tc.raw, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("normalizePhone(%q) = %q, seharusnya %q", tc.raw, got, tc.want)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"abc",
+		"0812abc4567",
+		"0812+34567890",     // + hanya boleh di depan
+		"++6281234567890",   // + ganda
+		"12345678901",       // nomor luar negeri tanpa +
+		"0812345",           // terlalu pendek setelah +62
+		"+62081234567890",   // diawali 0 setelah kode negara
+		"0812345678901234",  // lebih dari 12 digit setelah +62
+		"+1234567",          // kurang dari 8 digit
+		"+1234567890123456", // lebih dari 15 digit
+		"+0123456789",
+	}
+	for _, raw := range invalid {
+		got, err := normalizePhone(raw)
+		if err == nil {
+			t.Errorf("normalizePhone(%q) = %q, seharusnya ditolak", raw, got)
+			continue
+		}
+		assertStatus(t, err, http.StatusBadRequest)
+	}
+}
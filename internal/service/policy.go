@@ -0,0 +1,30 @@
+package service
+
+// Action adalah aksi yang dibatasi kebijakan akun
+type Action string
+
+const (
+	ActionCheckout  Action = "checkout"
+	ActionOpenStore Action = "open_store"
+	// ActionAdmin adalah akses ke route admin
+	ActionAdmin Action = "admin"
+)
+
+// AccountPolicy memutuskan apakah user boleh melakukan sebuah aksi. Error
+// yang dikembalikan berupa *Error dengan pesan untuk user.
+type AccountPolicy interface {
+	Allow(userID uint, action Action) error
+}
+
+// AccountPolicies menggabungkan beberapa kebijakan; aksi hanya boleh jika
+// semua kebijakan mengizinkan
+type AccountPolicies []AccountPolicy
+
+func (ps AccountPolicies) Allow(userID uint, action Action) error {
+	for _, p := range ps {
+		if err := p.Allow(userID, action); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"go-evermos/pkg"
+
+	"github.com/gosimple/slug"
+)
+
+// UploadDir adalah folder penyimpanan foto produk
+const UploadDir = "./uploads"
+
+type ProductInput struct {
+	NamaProduk    string `json:"nama_produk"`
+	HargaReseller string `json:"harga_reseller"`
+	HargaKonsumen string `json:"harga_konsumen"`
+	Stok          int    `json:"stok"`
+	StokMinimum   int    `json:"stok_minimum"`
+	Deskripsi     string `json:"deskripsi"`
+	IDCategory    uint   `json:"id_category"`
+	// Status hanya dipakai saat membuat produk (default active)
+	Status string `json:"-"`
+}
+
+type ProductService struct {
+	repos *repository.Repositories
+}
+
+func NewProductService(repos *repository.Repositories) *ProductService {
+	return &ProductService{repos: repos}
+}
+
+// IsOwnerProductStatus: status yang boleh dipilih sendiri oleh pemilik toko
+func IsOwnerProductStatus(status string) bool {
+	switch status {
+	case entities.ProductStatusDraft, entities.ProductStatusActive, entities.ProductStatusArchived:
+		return true
+	}
+	return false
+}
+
+// validateProductInput menolak stok negatif, sama seperti AdjustStock dan
+// import, supaya ledger dan file export selalu bisa di-import ulang
+func validateProductInput(input ProductInput) error {
+	if input.Stok < 0 {
+		return badRequest("Stok tidak boleh kurang dari 0")
+	}
+	if input.StokMinimum < 0 {
+		return badRequest("Stok minimum tidak boleh kurang dari 0")
+	}
+	return nil
+}
+
+// Create menyimpan produk baru di toko milik user, mencatat stok awal ke
+// ledger, dan menyimpan foto jika ada
+func (s *ProductService) Create(userID uint, input ProductInput, fotoPath string) (*entities.Product, error) {
+	if err := validateProductInput(input); err != nil {
+		return nil, err
+	}
+	store, err := ownedStore(s.repos, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Status == "" {
+		input.Status = entities.ProductStatusActive
+	}
+	if !IsOwnerProductStatus(input.Status) {
+		return nil, badRequest("Status harus draft, active atau archived")
+	}
+
+	produk := entities.Product{
+		NamaProduk:    input.NamaProduk,
+		Slug:          slug.Make(input.NamaProduk),
+		HargaReseller: input.HargaReseller,
+		HargaKonsumen: input.HargaKonsumen,
+		Deskripsi:     &input.Deskripsi,
+		IDToko:        store.ID,
+		IDCategory:    input.IDCategory,
+		Stok:          input.Stok,
+		StokMinimum:   input.StokMinimum,
+		Status:        input.Status,
+	}
+
+	err = s.repos.Transaction(func(tx *repository.Repositories) error {
+		if err := tx.Products.Create(&produk); err != nil {
+			return err
+		}
+		if err := recordStockMovement(tx, produk.ID, entities.StockMovementAdjustment, 0, produk.Stok, nil, &userID, "Stok awal"); err != nil {
+			return err
+		}
+
+		// simpan foto
+		if fotoPath != "" {
+			foto := entities.ProductPicture{
+				IDProduk: produk.ID,
+				Url:      fotoPath,
+			}
+			return tx.Products.CreatePicture(&foto)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &produk, nil
+}
+
+// ListPublic mengambil katalog publik (hanya produk active)
+func (s *ProductService) ListPublic(filter repository.ProductFilter) ([]entities.Product, int64, error) {
+	filter.Status = entities.ProductStatusActive
+	filter.IDToko = 0
+	return s.repos.Products.List(filter)
+}
+
+// ListMine mengambil semua produk toko user, termasuk draft/archived/banned
+func (s *ProductService) ListMine(userID uint, filter repository.ProductFilter) ([]entities.Product, int64, error) {
+	store, err := ownedStore(s.repos, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter.IDToko = store.ID
+	return s.repos.Products.List(filter)
+}
+
+// Get mengambil detail produk. Produk non-active hanya terlihat oleh pemilik
+// toko dan moderator katalog; viewer nil berarti pengunjung anonim.
+func (s *ProductService) Get(id uint, viewer *pkg.Principal) (*entities.Product, error) {
+	produk, err := s.repos.Products.FindDetail(id)
+	if err != nil {
+		return nil, orNotFound(err, "Produk tidak ditemukan")
+	}
+
+	if produk.Status != entities.ProductStatusActive {
+		if viewer == nil {
+			return nil, notFound("Produk tidak ditemukan")
+		}
+		if produk.Store.IDUser != viewer.UserID {
+			moderator, err := hasPermission(s.repos, viewer.UserID, pkg.PermProductModerate)
+			if err != nil {
+				return nil, err
+			}
+			if !moderator {
+				return nil, notFound("Produk tidak ditemukan")
+			}
+		}
+	}
+	return produk, nil
+}
+
+// Update mengubah produk milik user. Baris produk dikunci supaya perubahan
+// stok tidak menimpa reservasi checkout yang berjalan bersamaan. Perubahan
+// stok dicatat ke ledger dan memicu notifikasi "stok tersedia kembali".
+func (s *ProductService) Update(userID, id uint, input ProductInput) (*entities.Product, error) {
+	if err := validateProductInput(input); err != nil {
+		return nil, err
+	}
+
+	var produk *entities.Product
+	err := s.repos.Transaction(func(tx *repository.Repositories) error {
+		owned, err := tx.Products.FindOwned(id, userID)
+		if err != nil {
+			return orForbidden(err, "Produk tidak ditemukan atau bukan milik Anda")
+		}
+		produk, err = tx.Products.FindByIDForUpdate(owned.ID)
+		if err != nil {
+			return err
+		}
+
+		stokSebelum := produk.Stok
+		produk.NamaProduk = input.NamaProduk
+		produk.Slug = slug.Make(input.NamaProduk)
+		produk.HargaReseller = input.HargaReseller
+		produk.HargaKonsumen = input.HargaKonsumen
+		produk.Stok = input.Stok
+		produk.StokMinimum = input.StokMinimum
+		produk.Deskripsi = &input.Deskripsi
+		produk.IDCategory = input.IDCategory
+
+		if err := tx.Products.UpdateDetails(produk); err != nil {
+			return err
+		}
+		if produk.Stok == stokSebelum {
+			return nil
+		}
+		if err := tx.Products.UpdateStock(produk.ID, produk.Stok, produk.StokDipesan); err != nil {
+			return err
+		}
+		if err := recordStockMovement(tx, produk.ID, entities.StockMovementAdjustment, stokSebelum, produk.Stok, nil, &userID, "Update produk"); err != nil {
+			return err
+		}
+		return notifyBackInStock(tx, *produk, stokSebelum)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return produk, nil
+}
+
+func (s *ProductService) Delete(userID, id uint) error {
+	produk, err := s.repos.Products.FindOwned(id, userID)
+	if err != nil {
+		return orForbidden(err, "Produk tidak ditemukan atau bukan milik Anda")
+	}
+	return s.repos.Products.Delete(produk)
+}
+
+// UpdateStatus mengubah status publikasi oleh pemilik toko. Produk yang
+// diblokir admin tidak bisa diubah.
+func (s *ProductService) UpdateStatus(userID, id uint, status string) (*entities.Product, error) {
+	if !IsOwnerProductStatus(status) {
+		return nil, badRequest("Status harus draft, active atau archived")
+	}
+
+	produk, err := s.repos.Products.FindOwned(id, userID)
+	if err != nil {
+		return nil, orForbidden(err, "Produk tidak ditemukan atau bukan milik Anda")
+	}
+	if produk.Status == entities.ProductStatusBanned {
+		return nil, forbidden("Produk diblokir admin")
+	}
+
+	produk.Status = status
+	if err := s.repos.Products.UpdateStatus(produk.ID, produk.Status, produk.AlasanBan); err != nil {
+		return nil, err
+	}
+	return produk, nil
+}
+
+// Ban memblokir produk (admin) beserta alasannya
+func (s *ProductService) Ban(id uint, alasan string) (*entities.Product, error) {
+	if alasan == "" {
+		return nil, badRequest("Alasan wajib diisi")
+	}
+
+	produk, err := s.repos.Products.FindByID(id)
+	if err != nil {
+		return nil, orNotFound(err, "Produk tidak ditemukan")
+	}
+
+	produk.Status = entities.ProductStatusBanned
+	produk.AlasanBan = &alasan
+	if err := s.repos.Products.UpdateStatus(produk.ID, produk.Status, produk.AlasanBan); err != nil {
+		return nil, err
+	}
+	return produk, nil
+}
+
+// Unban membuka blokir produk. Produk kembali ke draft supaya pemilik toko
+// memeriksa ulang sebelum dipublikasikan.
+func (s *ProductService) Unban(id uint) (*entities.Product, error) {
+	produk, err := s.repos.Products.FindByID(id)
+	if err != nil || produk.Status != entities.ProductStatusBanned {
+		return nil, orNotFound(repository.ErrNotFound, "Produk yang diblokir tidak ditemukan")
+	}
+
+	produk.Status = entities.ProductStatusDraft
+	produk.AlasanBan = nil
+	if err := s.repos.Products.UpdateStatus(produk.ID, produk.Status, nil); err != nil {
+		return nil, err
+	}
+	return produk, nil
+}
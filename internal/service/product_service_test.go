@@ -0,0 +1,158 @@
+package service
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"go-evermos/pkg"
+	"net/http"
+	"testing"
+)
+
+func TestProductUpdateRequiresOwner(t *testing.T) {
+	repos := newTestRepos()
+	owner, store := seedUser(t, repos, "pemilik")
+	other, _ := seedUser(t, repos, "lain")
+	produk := seedProduct(t, repos, store.ID, 10, entities.ProductStatusActive)
+	s := NewProductService(repos)
+
+	input := ProductInput{NamaProduk: "Baru", HargaReseller: "800", HargaKonsumen: "1200", Stok: 10}
+	_, err := s.Update(other.ID, produk.ID, input)
+	assertStatus(t, err, http.StatusForbidden)
+
+	got, _ := repos.Products.FindByID(produk.ID)
+	if got.NamaProduk != "Produk" {
+		t.Errorf("nama %q, seharusnya tidak berubah", got.NamaProduk)
+	}
+
+	updated, err := s.Update(owner.ID, produk.ID, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.NamaProduk != "Baru" || updated.HargaKonsumen != "1200" {
+		t.Errorf("produk %q harga %s, seharusnya Baru dan 1200", updated.NamaProduk, updated.HargaKonsumen)
+	}
+}
+
+func TestProductUpdateKeepsReservation(t *testing.T) {
+	repos := newTestRepos()
+	owner, store := seedUser(t, repos, "pemilik")
+	produk := seedProduct(t, repos, store.ID, 10, entities.ProductStatusActive)
+	if err := repos.Products.UpdateStock(produk.ID, 7, 3); err != nil {
+		t.Fatal(err)
+	}
+	s := NewProductService(repos)
+
+	input := ProductInput{NamaProduk: "Baru", HargaReseller: "800", HargaKonsumen: "1200", Stok: 12}
+	if _, err := s.Update(owner.ID, produk.ID, input); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := repos.Products.FindByID(produk.ID)
+	if got.Stok != 12 || got.StokDipesan != 3 || got.Status != entities.ProductStatusActive {
+		t.Errorf("stok %d dipesan %d status %s, seharusnya 12, 3 dan active", got.Stok, got.StokDipesan, got.Status)
+	}
+	movements, _ := repos.Products.ListStockMovements(produk.ID, repository.StockMovementFilter{})
+	last := movements[0]
+	for _, m := range movements {
+		if m.ID > last.ID {
+			last = m
+		}
+	}
+	if last.StokSebelum != 7 || last.StokSesudah != 12 {
+		t.Errorf("ledger %d -> %d, seharusnya 7 -> 12", last.StokSebelum, last.StokSesudah)
+	}
+}
+
+func TestProductRejectsNegativeStock(t *testing.T) {
+	repos := newTestRepos()
+	owner, store := seedUser(t, repos, "pemilik")
+	produk := seedProduct(t, repos, store.ID, 10, entities.ProductStatusActive)
+	s := NewProductService(repos)
+
+	cases := []struct {
+		name  string
+		input ProductInput
+	}{
+		{"stok negatif", ProductInput{NamaProduk: "Baru", Stok: -5}},
+		{"stok minimum negatif", ProductInput{NamaProduk: "Baru", Stok: 5, StokMinimum: -1}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := s.Create(owner.ID, tc.input, "")
+			assertStatus(t, err, http.StatusBadRequest)
+			_, err = s.Update(owner.ID, produk.ID, tc.input)
+			assertStatus(t, err, http.StatusBadRequest)
+		})
+	}
+
+	got, _ := repos.Products.FindByID(produk.ID)
+	if got.Stok != 10 {
+		t.Errorf("stok %d, seharusnya tetap 10", got.Stok)
+	}
+}
+
+func TestProductDeleteRequiresOwner(t *testing.T) {
+	repos := newTestRepos()
+	owner, store := seedUser(t, repos, "pemilik")
+	other, _ := seedUser(t, repos, "lain")
+	produk := seedProduct(t, repos, store.ID, 10, entities.ProductStatusActive)
+	s := NewProductService(repos)
+
+	assertStatus(t, s.Delete(other.ID, produk.ID), http.StatusForbidden)
+	if _, err := repos.Products.FindByID(produk.ID); err != nil {
+		t.Fatalf("produk terhapus oleh bukan pemilik: %v", err)
+	}
+
+	if err := s.Delete(owner.ID, produk.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repos.Products.FindByID(produk.ID); err == nil {
+		t.Error("produk masih ada setelah dihapus pemilik")
+	}
+}
+
+func TestProductUpdateStatusRejectsBanned(t *testing.T) {
+	repos := newTestRepos()
+	owner, store := seedUser(t, repos, "pemilik")
+	produk := seedProduct(t, repos, store.ID, 10, entities.ProductStatusBanned)
+	s := NewProductService(repos)
+
+	_, err := s.UpdateStatus(owner.ID, produk.ID, entities.ProductStatusActive)
+	assertStatus(t, err, http.StatusForbidden)
+	_, err = s.UpdateStatus(owner.ID, produk.ID, entities.ProductStatusBanned)
+	assertStatus(t, err, http.StatusBadRequest)
+}
+
+func TestProductGetHidesInactive(t *testing.T) {
+	repos := newTestRepos()
+	owner, store := seedUser(t, repos, "pemilik")
+	other, _ := seedUser(t, repos, "lain")
+	moderator, _ := seedUser(t, repos, "moderator")
+	if err := repos.UserRoles.Create(&entities.UserRole{IDUser: moderator.ID, Role: pkg.RoleCatalogModerator}); err != nil {
+		t.Fatal(err)
+	}
+	produk := seedProduct(t, repos, store.ID, 10, entities.ProductStatusDraft)
+	s := NewProductService(repos)
+
+	cases := []struct {
+		name    string
+		viewer  *pkg.Principal
+		visible bool
+	}{
+		{"anonim", nil, false},
+		{"user lain", &pkg.Principal{UserID: other.ID}, false},
+		{"pemilik", &pkg.Principal{UserID: owner.ID}, true},
+		{"moderator katalog", &pkg.Principal{UserID: moderator.ID}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := s.Get(produk.ID, tc.viewer)
+			if tc.visible && err != nil {
+				t.Errorf("seharusnya terlihat: %v", err)
+			}
+			if !tc.visible {
+				assertStatus(t, err, http.StatusNotFound)
+			}
+		})
+	}
+}
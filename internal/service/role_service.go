@@ -0,0 +1,182 @@
+package service
+
+import (
+	"errors"
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"go-evermos/pkg"
+	"slices"
+)
+
+// RoleInfo adalah satu role beserta izinnya
+type RoleInfo struct {
+	Role        string
+	Permissions []pkg.Permission
+	// Grantable bernilai false untuk role yang diturunkan otomatis
+	// (buyer dan seller)
+	Grantable bool
+}
+
+// RoleService mengelola role yang diberikan admin dan menyediakan role
+// terbaru untuk pemeriksaan izin
+type RoleService struct {
+	repos *repository.Repositories
+}
+
+func NewRoleService(repos *repository.Repositories) *RoleService {
+	return &RoleService{repos: repos}
+}
+
+// Catalog mengembalikan semua role dan izinnya
+func (s *RoleService) Catalog() []RoleInfo {
+	order := []string{
+		pkg.RoleAdmin, pkg.RoleCatalogModerator, pkg.RoleSupport, pkg.RoleFinance,
+		pkg.RoleSeller, pkg.RoleReseller, pkg.RoleBuyer,
+	}
+	roles := make([]RoleInfo, 0, len(order))
+	for _, r := range order {
+		roles = append(roles, RoleInfo{
+			Role:        r,
+			Permissions: pkg.RolePermissions[r],
+			Grantable:   slices.Contains(pkg.GrantableRoles, r),
+		})
+	}
+	return roles
+}
+
+// CurrentRoles dipakai pkg.RequirePermission untuk membaca role terbaru
+func (s *RoleService) CurrentRoles(userID uint) ([]string, error) {
+	return userRoles(s.repos, userID)
+}
+
+// Roles mengambil role user untuk ditampilkan ke admin
+func (s *RoleService) Roles(userID uint) ([]string, error) {
+	if _, err := s.repos.Users.FindByID(userID); err != nil {
+		return nil, orNotFound(err, "User tidak ditemukan")
+	}
+	return userRoles(s.repos, userID)
+}
+
+// Grant memberikan role ke user. actorID 0 berarti diberikan lewat command
+// line. Memberikan role yang sudah dimiliki tidak dianggap error.
+func (s *RoleService) Grant(actorID, userID uint, role string) ([]string, error) {
+	if !slices.Contains(pkg.GrantableRoles, role) {
+		return nil, badRequest("Role tidak dikenal atau tidak bisa diberikan")
+	}
+
+	var roles []string
+	err := s.repos.Transaction(func(tx *repository.Repositories) error {
+		if _, err := tx.Users.FindByID(userID); err != nil {
+			return orNotFound(err, "User tidak ditemukan")
+		}
+		granted, err := grantedRoles(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		if !slices.Contains(granted, role) {
+			ur := &entities.UserRole{IDUser: userID, Role: role}
+			if actorID != 0 {
+				ur.IDPemberi = &actorID
+			}
+			if err := tx.UserRoles.Create(ur); err != nil {
+				return err
+			}
+		}
+		roles, err = userRoles(tx, userID)
+		return err
+	})
+	return roles, err
+}
+
+// Revoke mencabut role dari user. Admin aktif terakhir tidak bisa dicabut
+// supaya selalu ada yang bisa mengelola role.
+func (s *RoleService) Revoke(userID uint, role string) ([]string, error) {
+	if !slices.Contains(pkg.GrantableRoles, role) {
+		return nil, badRequest("Role tidak dikenal atau tidak bisa dicabut")
+	}
+
+	var roles []string
+	err := s.repos.Transaction(func(tx *repository.Repositories) error {
+		if _, err := tx.Users.FindByID(userID); err != nil {
+			return orNotFound(err, "User tidak ditemukan")
+		}
+		granted, err := grantedRoles(tx, userID)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(granted, role) {
+			return notFound("User tidak punya role tersebut")
+		}
+
+		if role == pkg.RoleAdmin {
+			admins, err := tx.UserRoles.CountUsers(pkg.RoleAdmin)
+			if err != nil {
+				return err
+			}
+			if admins <= 1 {
+				return badRequest("Admin terakhir tidak bisa dicabut")
+			}
+		}
+
+		if err := tx.UserRoles.Delete(userID, role); err != nil {
+			return err
+		}
+		roles, err = userRoles(tx, userID)
+		return err
+	})
+	return roles, err
+}
+
+// grantedRoles mengambil role yang diberikan admin
+func grantedRoles(repos *repository.Repositories, userID uint) ([]string, error) {
+	rows, err := repos.UserRoles.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]string, 0, len(rows))
+	for _, r := range rows {
+		roles = append(roles, r.Role)
+	}
+	return roles, nil
+}
+
+// userRoles mengambil semua role user: role implisit dan role yang diberikan
+func userRoles(repos *repository.Repositories, userID uint) ([]string, error) {
+	hasStore := true
+	if _, err := repos.Stores.FindByUserID(userID); err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		hasStore = false
+	}
+
+	granted, err := grantedRoles(repos, userID)
+	if err != nil {
+		return nil, err
+	}
+	return pkg.UserRoles(hasStore, granted), nil
+}
+
+// hasPermission mengecek izin user dari role terbarunya
+func hasPermission(repos *repository.Repositories, userID uint, perm pkg.Permission) (bool, error) {
+	roles, err := userRoles(repos, userID)
+	if err != nil {
+		return false, err
+	}
+	return pkg.RolesAllow(roles, perm), nil
+}
+
+// isStaff mengecek apakah user punya role yang bisa mengakses route admin
+func isStaff(repos *repository.Repositories, userID uint) (bool, error) {
+	granted, err := grantedRoles(repos, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range granted {
+		if slices.Contains(pkg.StaffRoles, r) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"fmt"
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+)
+
+type AdjustStockInput struct {
+	Jumlah  int    `json:"jumlah"`
+	Tipe    string `json:"tipe"`
+	IDTrx   *uint  `json:"id_trx"`
+	Catatan string `json:"catatan"`
+}
+
+// StockHistory adalah riwayat mutasi stok beserta hasil cek ledger
+type StockHistory struct {
+	Produk     *entities.Product
+	StokLedger int
+	Riwayat    []entities.StockMovement
+}
+
+// recordStockMovement mencatat perubahan stok ke ledger. Dipanggil di dalam
+// transaksi yang sama dengan perubahan Product.Stok.
+func recordStockMovement(repos *repository.Repositories, idProduk uint, tipe string, sebelum, sesudah int, idTrx, idUser *uint, catatan string) error {
+	movement := entities.StockMovement{
+		IDProduk:    idProduk,
+		Tipe:        tipe,
+		Jumlah:      sesudah - sebelum,
+		StokSebelum: sebelum,
+		StokSesudah: sesudah,
+		IDTrx:       idTrx,
+		IDUser:      idUser,
+	}
+	if catatan != "" {
+		movement.Catatan = &catatan
+	}
+	return repos.Products.CreateStockMovement(&movement)
+}
+
+// notifyLowStock memberi tahu pemilik toko saat stok turun melewati batas minimum
+func notifyLowStock(repos *repository.Repositories, produk entities.Product, sebelum int) error {
+	if produk.StokMinimum <= 0 || sebelum < produk.StokMinimum || produk.Stok >= produk.StokMinimum {
+		return nil
+	}
+
+	store, err := repos.Stores.FindByID(produk.IDToko)
+	if err != nil {
+		return nil
+	}
+
+	return repos.Notifications.Create(&entities.Notification{
+		IDUser:   store.IDUser,
+		Tipe:     entities.NotificationLowStock,
+		Judul:    "Stok menipis",
+		Pesan:    fmt.Sprintf("Stok produk %s tinggal %d (batas minimum %d)", produk.NamaProduk, produk.Stok, produk.StokMinimum),
+		IDProduk: &produk.ID,
+	})
+}
+
+// notifyBackInStock memberi tahu pelanggan saat stok produk naik dari 0.
+// Langganan dihapus setelah notifikasi dibuat.
+func notifyBackInStock(repos *repository.Repositories, produk entities.Product, sebelum int) error {
+	if sebelum > 0 || produk.Stok <= 0 {
+		return nil
+	}
+
+	subs, err := repos.Products.ListSubscribers(produk.ID)
+	if err != nil || len(subs) == 0 {
+		return err
+	}
+
+	notifs := make([]*entities.Notification, 0, len(subs))
+	for _, sub := range subs {
+		notifs = append(notifs, &entities.Notification{
+			IDUser:   sub.IDUser,
+			Tipe:     entities.NotificationBackInStock,
+			Judul:    "Produk tersedia kembali",
+			Pesan:    fmt.Sprintf("Produk %s sudah tersedia kembali", produk.NamaProduk),
+			IDProduk: &produk.ID,
+		})
+	}
+	if err := repos.Notifications.Create(notifs...); err != nil {
+		return err
+	}
+
+	return repos.Products.ClearSubscribers(produk.ID)
+}
+
+// StockHistory menampilkan riwayat mutasi stok produk milik user
+func (s *ProductService) StockHistory(userID, id uint, filter repository.StockMovementFilter) (*StockHistory, error) {
+	produk, err := s.repos.Products.FindOwned(id, userID)
+	if err != nil {
+		return nil, orForbidden(err, "Produk tidak ditemukan atau bukan milik Anda")
+	}
+
+	movements, err := s.repos.Products.ListStockMovements(produk.ID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	stokLedger, err := s.repos.Products.LedgerStock(produk.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StockHistory{Produk: produk, StokLedger: stokLedger, Riwayat: movements}, nil
+}
+
+// AdjustStock mengubah stok secara manual (koreksi stok atau retur barang)
+func (s *ProductService) AdjustStock(userID, id uint, input AdjustStockInput) (*entities.Product, error) {
+	if input.Tipe == "" {
+		input.Tipe = entities.StockMovementAdjustment
+	}
+	if input.Tipe != entities.StockMovementAdjustment && input.Tipe != entities.StockMovementReturn {
+		return nil, badRequest("Tipe harus adjustment atau return")
+	}
+	if input.Jumlah == 0 {
+		return nil, badRequest("Jumlah tidak boleh 0")
+	}
+	if input.Tipe == entities.StockMovementReturn && input.Jumlah < 0 {
+		return nil, badRequest("Jumlah retur harus positif")
+	}
+
+	var produk *entities.Product
+	err := s.repos.Transaction(func(tx *repository.Repositories) error {
+		owned, err := tx.Products.FindOwned(id, userID)
+		if err != nil {
+			return orForbidden(err, "Produk tidak ditemukan atau bukan milik Anda")
+		}
+		produk, err = tx.Products.FindByIDForUpdate(owned.ID)
+		if err != nil {
+			return err
+		}
+
+		if input.IDTrx != nil {
+			if err := checkAdjustmentTrx(tx, *input.IDTrx, produk.ID); err != nil {
+				return err
+			}
+		}
+
+		sebelum := produk.Stok
+		if sebelum+input.Jumlah < 0 {
+			return badRequest("Stok tidak boleh kurang dari 0")
+		}
+		produk.Stok = sebelum + input.Jumlah
+
+		if err := tx.Products.UpdateStock(produk.ID, produk.Stok, produk.StokDipesan); err != nil {
+			return err
+		}
+		if err := recordStockMovement(tx, produk.ID, input.Tipe, sebelum, produk.Stok, input.IDTrx, &userID, input.Catatan); err != nil {
+			return err
+		}
+		return notifyBackInStock(tx, *produk, sebelum)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return produk, nil
+}
+
+// checkAdjustmentTrx memastikan transaksi yang dirujuk penyesuaian stok ada
+// dan memuat produk tersebut, supaya penjual tidak bisa mengaitkan mutasi ke
+// transaksi toko lain
+func checkAdjustmentTrx(tx *repository.Repositories, idTrx, idProduk uint) error {
+	items, err := tx.Transactions.ReservedItems(idTrx)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if item.IDProduk == idProduk {
+			return nil
+		}
+	}
+	return badRequest("Transaksi tidak ditemukan atau tidak memuat produk ini")
+}
+
+// ReconcileStock membandingkan stok setiap produk toko dengan jumlah mutasi
+// di ledger. Mengembalikan jumlah produk yang dicek dan daftar yang selisih.
+func (s *ProductService) ReconcileStock(userID uint) (int, []repository.StockReconcileRow, error) {
+	store, err := ownedStore(s.repos, userID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	rows, err := s.repos.Products.ReconcileStore(store.ID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	mismatches := []repository.StockReconcileRow{}
+	for _, row := range rows {
+		if row.Stok != row.StokLedger {
+			row.Selisih = row.Stok - row.StokLedger
+			mismatches = append(mismatches, row)
+		}
+	}
+	return len(rows), mismatches, nil
+}
+
+// SubscribeRestock mendaftarkan user untuk diberi tahu saat produk tersedia kembali
+func (s *ProductService) SubscribeRestock(userID, id uint) error {
+	produk, err := s.repos.Products.FindByID(id)
+	if err != nil || produk.Status != entities.ProductStatusActive {
+		return notFound("Produk tidak ditemukan")
+	}
+	if produk.Stok > 0 {
+		return badRequest("Produk masih tersedia")
+	}
+
+	return s.repos.Products.Subscribe(&entities.StockSubscription{IDUser: userID, IDProduk: produk.ID})
+}
+
+// UnsubscribeRestock membatalkan langganan notifikasi stok
+func (s *ProductService) UnsubscribeRestock(userID, id uint) error {
+	return s.repos.Products.Unsubscribe(id, userID)
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"go-evermos/internal/entities"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAdjustStockChecksTransaction(t *testing.T) {
+	repos := newTestRepos()
+	seller, store := seedUser(t, repos, "penjual")
+	_, otherStore := seedUser(t, repos, "lain")
+	buyer, _ := seedUser(t, repos, "pembeli")
+	produk := seedProduct(t, repos, store.ID, 10, entities.ProductStatusActive)
+	otherProduk := seedProduct(t, repos, otherStore.ID, 10, entities.ProductStatusActive)
+
+	trxs := NewTransactionService(repos, time.Hour, allowAll{})
+	own, _, err := trxs.Checkout(buyer.ID, checkoutRequest(seedAddress(t, repos, buyer.ID), [2]int{int(produk.ID), 2}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _, err := trxs.Checkout(buyer.ID, checkoutRequest(seedAddress(t, repos, buyer.ID), [2]int{int(otherProduk.ID), 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing := uint(999)
+
+	s := NewProductService(repos)
+	for name, idTrx := range map[string]*uint{"transaksi toko lain": &other.ID, "transaksi tidak ada": &missing} {
+		t.Run(name, func(t *testing.T) {
+			_, err := s.AdjustStock(seller.ID, produk.ID, AdjustStockInput{Jumlah: 1, Tipe: entities.StockMovementReturn, IDTrx: idTrx})
+			assertStatus(t, err, http.StatusBadRequest)
+		})
+	}
+
+	got, err := s.AdjustStock(seller.ID, produk.ID, AdjustStockInput{Jumlah: 1, Tipe: entities.StockMovementReturn, IDTrx: &own.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Stok != 9 {
+		t.Errorf("stok %d, seharusnya 9 (10 - 2 dipesan + 1 retur)", got.Stok)
+	}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+)
+
+type UpdateStoreInput struct {
+	NamaToko string `json:"nama_toko"`
+	UrlFoto  string `json:"url_foto"`
+}
+
+type StoreService struct {
+	repos *repository.Repositories
+}
+
+func NewStoreService(repos *repository.Repositories) *StoreService {
+	return &StoreService{repos: repos}
+}
+
+// GetByUser mengambil toko milik user
+func (s *StoreService) GetByUser(userID uint) (*entities.Store, error) {
+	store, err := s.repos.Stores.FindByUserID(userID)
+	if err != nil {
+		return nil, orNotFound(err, "Toko tidak ditemukan")
+	}
+	return store, nil
+}
+
+// Update mengubah nama/foto toko. Field kosong tidak diubah.
+func (s *StoreService) Update(userID uint, input UpdateStoreInput) (*entities.Store, error) {
+	store, err := s.GetByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.NamaToko != "" {
+		store.NamaToko = &input.NamaToko
+	}
+	if input.UrlFoto != "" {
+		store.UrlFoto = &input.UrlFoto
+	}
+
+	if err := s.repos.Stores.Save(store); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// ownedStore mengambil toko user untuk operasi penjual (403 jika belum punya toko)
+func ownedStore(repos *repository.Repositories, userID uint) (*entities.Store, error) {
+	store, err := repos.Stores.FindByUserID(userID)
+	if err != nil {
+		return nil, orForbidden(err, "Toko tidak ditemukan")
+	}
+	return store, nil
+}
@@ -0,0 +1,362 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"go-evermos/pkg"
+	"log"
+	"slices"
+	"time"
+)
+
+// Request body untuk checkout
+type CheckoutRequest struct {
+	IDAlamat    uint           `json:"id_alamat"`
+	MethodBayar string         `json:"method_bayar"`
+	Items       []CheckoutItem `json:"items"`
+}
+
+type CheckoutItem struct {
+	IDProduk uint `json:"id_produk"`
+	Qty      int  `json:"qty"`
+}
+
+type TransactionService struct {
+	repos         *repository.Repositories
+	paymentWindow time.Duration
+	policy        AccountPolicy
+}
+
+// NewTransactionService membuat service transaksi. paymentWindow adalah lama
+// stok dipesan sebelum transaksi pending di-expire; policy menentukan siapa
+// yang boleh checkout.
+func NewTransactionService(repos *repository.Repositories, paymentWindow time.Duration, policy AccountPolicy) *TransactionService {
+	return &TransactionService{repos: repos, paymentWindow: paymentWindow, policy: policy}
+}
+
+// Checkout membuat transaksi pending. Stok setiap produk dipindah ke
+// StokDipesan sampai transaksi dibayar, dibatalkan, atau lewat batas bayar.
+func (s *TransactionService) Checkout(userID uint, req CheckoutRequest) (*entities.Trx, []entities.TrxDetail, error) {
+	if err := s.policy.Allow(userID, ActionCheckout); err != nil {
+		return nil, nil, err
+	}
+	if len(req.Items) == 0 {
+		return nil, nil, badRequest("Item tidak boleh kosong")
+	}
+	if _, err := s.repos.Addresses.FindForUser(req.IDAlamat, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil, badRequest("Alamat pengiriman tidak ditemukan")
+		}
+		return nil, nil, err
+	}
+
+	// Produk dikunci urut ID supaya dua checkout dengan produk yang sama
+	// dalam urutan berbeda tidak saling menunggu (deadlock)
+	items := slices.Clone(req.Items)
+	slices.SortStableFunc(items, func(a, b CheckoutItem) int { return cmp.Compare(a.IDProduk, b.IDProduk) })
+
+	// reseller membayar harga reseller, user lain harga konsumen
+	resellerPrice, err := hasPermission(s.repos, userID, pkg.PermResellerPrice)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var totalHarga int
+	var trxDetails []entities.TrxDetail
+	var trx entities.Trx
+
+	// mutasi stok dicatat setelah transaksi punya ID
+	type stokKeluar struct {
+		idProduk uint
+		sebelum  int
+		sesudah  int
+	}
+	var mutasi []stokKeluar
+
+	err = s.repos.Transaction(func(tx *repository.Repositories) error {
+		// Proses tiap produk
+		for _, item := range items {
+			if item.Qty <= 0 {
+				return badRequest("Qty harus lebih dari 0")
+			}
+
+			produk, err := tx.Products.FindByIDForUpdate(item.IDProduk)
+			if err != nil {
+				return orNotFound(err, fmt.Sprintf("Produk %d tidak ditemukan", item.IDProduk))
+			}
+			if produk.Status != entities.ProductStatusActive {
+				return badRequest(fmt.Sprintf("Produk %s tidak tersedia", produk.NamaProduk))
+			}
+
+			// Kurangi stok
+			if produk.Stok < item.Qty {
+				return badRequest(fmt.Sprintf("Stok produk %s tidak mencukupi", produk.NamaProduk))
+			}
+			// stok dipindah ke StokDipesan sampai transaksi dibayar
+			sebelum := produk.Stok
+			produk.Stok -= item.Qty
+			produk.StokDipesan += item.Qty
+			if err := tx.Products.UpdateStock(produk.ID, produk.Stok, produk.StokDipesan); err != nil {
+				return err
+			}
+			mutasi = append(mutasi, stokKeluar{produk.ID, sebelum, produk.Stok})
+			if err := notifyLowStock(tx, *produk, sebelum); err != nil {
+				return err
+			}
+
+			// Simpan ke ProductLog
+			prodLog := entities.ProductLog{
+				IDProduk:      produk.ID,
+				NamaProduk:    produk.NamaProduk,
+				Slug:          produk.Slug,
+				HargaReseller: produk.HargaReseller,
+				HargaKonsumen: produk.HargaKonsumen,
+				Deskripsi:     produk.Deskripsi,
+				IDToko:        produk.IDToko,
+				IDCategory:    produk.IDCategory,
+			}
+			if err := tx.Transactions.CreateProductLog(&prodLog); err != nil {
+				return err
+			}
+
+			// Hitung harga total per item
+			// asumsi harga = string → kita pakai parseInt
+			harga := produk.HargaKonsumen
+			if resellerPrice {
+				harga = produk.HargaReseller
+			}
+			var hargaInt int
+			fmt.Sscan(harga, &hargaInt)
+			hargaTotalItem := hargaInt * item.Qty
+			totalHarga += hargaTotalItem
+
+			// Buat detail
+			trxDetails = append(trxDetails, entities.TrxDetail{
+				IDLogProduk: prodLog.ID,
+				IDToko:      produk.IDToko,
+				Kuantitas:   item.Qty,
+				HargaTotal:  hargaTotalItem,
+			})
+		}
+
+		// Buat transaksi utama
+		batasBayar := time.Now().Add(s.paymentWindow)
+		trx = entities.Trx{
+			IDUser:           userID,
+			AlamatPengiriman: req.IDAlamat,
+			HargaTotal:       totalHarga,
+			KodeInvoice:      fmt.Sprintf("INV-%d", time.Now().Unix()),
+			MethodBayar:      req.MethodBayar,
+			StatusBayar:      entities.TrxStatusPending,
+			BatasBayar:       &batasBayar,
+		}
+		if err := tx.Transactions.Create(&trx); err != nil {
+			return err
+		}
+
+		// Simpan detail transaksi
+		for i := range trxDetails {
+			trxDetails[i].IDTrx = trx.ID
+			if err := tx.Transactions.CreateDetail(&trxDetails[i]); err != nil {
+				return err
+			}
+		}
+
+		// Catat stok keluar ke ledger
+		for _, m := range mutasi {
+			if err := recordStockMovement(tx, m.idProduk, entities.StockMovementSale, m.sebelum, m.sesudah, &trx.ID, &userID, trx.KodeInvoice); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &trx, trxDetails, nil
+}
+
+// List mengambil transaksi milik user (dengan pagination & filter)
+func (s *TransactionService) List(userID uint, filter repository.TrxFilter) ([]entities.Trx, error) {
+	return s.repos.Transactions.ListForUser(userID, filter)
+}
+
+// ListAll mengambil transaksi semua user untuk route admin
+func (s *TransactionService) ListAll(filter repository.TrxFilter) ([]entities.Trx, error) {
+	return s.repos.Transactions.List(filter)
+}
+
+// Get mengambil detail transaksi milik user
+func (s *TransactionService) Get(userID, id uint) (*entities.Trx, error) {
+	trx, err := s.repos.Transactions.FindForUser(id, userID)
+	if err != nil {
+		return nil, orNotFound(err, "Transaksi tidak ditemukan")
+	}
+	return trx, nil
+}
+
+// lockPendingTrx mengunci transaksi dan memastikan statusnya masih pending.
+// userID 0 berarti tanpa cek pemilik (dipakai worker).
+func lockPendingTrx(tx *repository.Repositories, id, userID uint) (*entities.Trx, error) {
+	trx, err := tx.Transactions.FindForUpdate(id)
+	if err != nil {
+		return nil, orNotFound(err, "Transaksi tidak ditemukan")
+	}
+	if userID != 0 && trx.IDUser != userID {
+		return nil, notFound("Transaksi tidak ditemukan")
+	}
+	if trx.StatusBayar != entities.TrxStatusPending {
+		return nil, badRequest("Transaksi sudah " + trx.StatusBayar)
+	}
+	return trx, nil
+}
+
+// releaseReservation mengembalikan stok yang dipesan transaksi pending ke stok
+// tersedia, lalu menandai transaksi dengan status akhir (expired/cancelled).
+// Pelanggan produk yang kembali tersedia diberi notifikasi.
+func releaseReservation(tx *repository.Repositories, trx *entities.Trx, status string, idUser *uint) error {
+	items, err := tx.Transactions.ReservedItems(trx.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		produk, err := tx.Products.FindByIDForUpdate(item.IDProduk)
+		if err != nil {
+			// produk sudah dihapus, tidak ada stok yang perlu dikembalikan
+			continue
+		}
+
+		sebelum := produk.Stok
+		produk.Stok += item.Kuantitas
+		produk.StokDipesan = max(produk.StokDipesan-item.Kuantitas, 0)
+		if err := tx.Products.UpdateStock(produk.ID, produk.Stok, produk.StokDipesan); err != nil {
+			return err
+		}
+		if err := recordStockMovement(tx, produk.ID, entities.StockMovementCancelRestock, sebelum, produk.Stok, &trx.ID, idUser, "Transaksi "+status); err != nil {
+			return err
+		}
+		if err := notifyBackInStock(tx, *produk, sebelum); err != nil {
+			return err
+		}
+	}
+
+	trx.StatusBayar = status
+	return tx.Transactions.UpdatePayment(trx)
+}
+
+// confirmReservation menjadikan stok yang dipesan sebagai terjual permanen
+func confirmReservation(tx *repository.Repositories, trx *entities.Trx) error {
+	items, err := tx.Transactions.ReservedItems(trx.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if err := tx.Products.ReleaseReserved(item.IDProduk, item.Kuantitas); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	trx.StatusBayar = entities.TrxStatusPaid
+	trx.TanggalBayar = &now
+	return tx.Transactions.UpdatePayment(trx)
+}
+
+// Pay menandai transaksi pending sebagai sudah dibayar setelah pembayaran
+// dikonfirmasi staf (bukan oleh pembeli). Jika batas bayar sudah lewat, stok
+// tetap dilepas (transaksi DB di-commit) dan pembayaran ditolak.
+func (s *TransactionService) Pay(id uint) (*entities.Trx, error) {
+	var trx *entities.Trx
+	expired := false
+	err := s.repos.Transaction(func(tx *repository.Repositories) error {
+		var err error
+		trx, err = lockPendingTrx(tx, id, 0)
+		if err != nil {
+			return err
+		}
+		if trx.BatasBayar != nil && trx.BatasBayar.Before(time.Now()) {
+			expired = true
+			return releaseReservation(tx, trx, entities.TrxStatusExpired, nil)
+		}
+		return confirmReservation(tx, trx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if expired {
+		return nil, badRequest("Batas waktu pembayaran sudah lewat")
+	}
+	return trx, nil
+}
+
+// Cancel membatalkan transaksi pending dan melepas stok yang dipesan
+func (s *TransactionService) Cancel(userID, id uint) (*entities.Trx, error) {
+	var trx *entities.Trx
+	err := s.repos.Transaction(func(tx *repository.Repositories) error {
+		var err error
+		trx, err = lockPendingTrx(tx, id, userID)
+		if err != nil {
+			return err
+		}
+		return releaseReservation(tx, trx, entities.TrxStatusCancelled, &userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return trx, nil
+}
+
+// ExpireReservations melepas stok semua transaksi pending yang lewat batas bayar.
+// Mengembalikan jumlah transaksi yang di-expire.
+func (s *TransactionService) ExpireReservations() (int, error) {
+	ids, err := s.repos.Transactions.ListExpiredPending(time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, id := range ids {
+		err := s.repos.Transaction(func(tx *repository.Repositories) error {
+			trx, err := lockPendingTrx(tx, id, 0)
+			if err != nil {
+				// sudah dibayar/dibatalkan di antara query dan lock
+				return nil
+			}
+			if err := releaseReservation(tx, trx, entities.TrxStatusExpired, nil); err != nil {
+				return err
+			}
+			expired++
+			return nil
+		})
+		if err != nil {
+			log.Println("Gagal expire transaksi", id, ":", err)
+		}
+	}
+	return expired, nil
+}
+
+// StartReservationWorker menjalankan ExpireReservations secara berkala sampai ctx selesai
+func (s *TransactionService) StartReservationWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := s.ExpireReservations()
+			if err != nil {
+				log.Println("Gagal cek reservasi stok:", err)
+			} else if n > 0 {
+				log.Printf("%d transaksi expired, stok dikembalikan\n", n)
+			}
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"go-evermos/pkg"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func checkoutRequest(idAlamat uint, items ...[2]int) CheckoutRequest {
+	req := CheckoutRequest{IDAlamat: idAlamat, MethodBayar: "cod"}
+	for _, it := range items {
+		req.Items = append(req.Items, CheckoutItem{IDProduk: uint(it[0]), Qty: it[1]})
+	}
+	return req
+}
+
+func TestCheckoutReservesStock(t *testing.T) {
+	repos := newTestRepos()
+	_, store := seedUser(t, repos, "penjual")
+	buyer, _ := seedUser(t, repos, "pembeli")
+	produk := seedProduct(t, repos, store.ID, 10, entities.ProductStatusActive)
+	s := NewTransactionService(repos, time.Hour, allowAll{})
+
+	trx, details, err := s.Checkout(buyer.ID, checkoutRequest(seedAddress(t, repos, buyer.ID), [2]int{int(produk.ID), 3}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trx.StatusBayar != entities.TrxStatusPending || trx.BatasBayar == nil {
+		t.Errorf("transaksi %s tanpa batas bayar, seharusnya pending dengan batas bayar", trx.StatusBayar)
+	}
+	if trx.HargaTotal != 3000 || len(details) != 1 || details[0].HargaTotal != 3000 {
+		t.Errorf("harga total %d, seharusnya 3000 (harga konsumen)", trx.HargaTotal)
+	}
+
+	got, _ := repos.Products.FindByID(produk.ID)
+	if got.Stok != 7 || got.StokDipesan != 3 {
+		t.Errorf("stok %d dipesan %d, seharusnya 7 dan 3", got.Stok, got.StokDipesan)
+	}
+	movements, _ := repos.Products.ListStockMovements(produk.ID, repository.StockMovementFilter{})
+	if len(movements) != 1 || movements[0].Tipe != entities.StockMovementSale || movements[0].Jumlah != -3 {
+		t.Errorf("ledger %+v, seharusnya satu mutasi penjualan -3", movements)
+	}
+}
+
+func TestCheckoutResellerPrice(t *testing.T) {
+	repos := newTestRepos()
+	_, store := seedUser(t, repos, "penjual")
+	reseller, _ := seedUser(t, repos, "reseller")
+	produk := seedProduct(t, repos, store.ID, 10, entities.ProductStatusActive)
+	if err := repos.UserRoles.Create(&entities.UserRole{IDUser: reseller.ID, Role: pkg.RoleReseller}); err != nil {
+		t.Fatal(err)
+	}
+	s := NewTransactionService(repos, time.Hour, allowAll{})
+
+	trx, _, err := s.Checkout(reseller.ID, checkoutRequest(seedAddress(t, repos, reseller.ID), [2]int{int(produk.ID), 2}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trx.HargaTotal != 1400 {
+		t.Errorf("harga total %d, seharusnya 1400 (harga reseller)", trx.HargaTotal)
+	}
+}
+
+func TestCheckoutRejected(t *testing.T) {
+	cases := []struct {
+		name   string
+		status string
+		stok   int
+		qty    int
+	}{
+		{"qty nol", entities.ProductStatusActive, 10, 0},
+		{"qty negatif", entities.ProductStatusActive, 10, -1},
+		{"stok kurang", entities.ProductStatusActive, 2, 3},
+		{"produk draft", entities.ProductStatusDraft, 10, 1},
+		{"produk archived", entities.ProductStatusArchived, 10, 1},
+		{"produk diblokir", entities.ProductStatusBanned, 10, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repos := newTestRepos()
+			_, store := seedUser(t, repos, "penjual")
+			buyer, _ := seedUser(t, repos, "pembeli")
+			produk := seedProduct(t, repos, store.ID, tc.stok, tc.status)
+			s := NewTransactionService(repos, time.Hour, allowAll{})
+
+			_, _, err := s.Checkout(buyer.ID, checkoutRequest(seedAddress(t, repos, buyer.ID), [2]int{int(produk.ID), tc.qty}))
+			assertStatus(t, err, http.StatusBadRequest)
+
+			got, _ := repos.Products.FindByID(produk.ID)
+			if got.Stok != tc.stok || got.StokDipesan != 0 {
+				t.Errorf("stok %d dipesan %d, seharusnya tidak berubah", got.Stok, got.StokDipesan)
+			}
+		})
+	}
+}
+
+func TestCheckoutRollsBackOnFailedItem(t *testing.T) {
+	repos := newTestRepos()
+	_, store := seedUser(t, repos, "penjual")
+	buyer, _ := seedUser(t, repos, "pembeli")
+	ok := seedProduct(t, repos, store.ID, 10, entities.ProductStatusActive)
+	habis := seedProduct(t, repos, store.ID, 1, entities.ProductStatusActive)
+	s := NewTransactionService(repos, time.Hour, allowAll{})
+
+	_, _, err := s.Checkout(buyer.ID, checkoutRequest(seedAddress(t, repos, buyer.ID), [2]int{int(ok.ID), 2}, [2]int{int(habis.ID), 5}))
+	assertStatus(t, err, http.StatusBadRequest)
+
+	got, _ := repos.Products.FindByID(ok.ID)
+	if got.Stok != 10 || got.StokDipesan != 0 {
+		t.Errorf("stok %d dipesan %d, seharusnya dikembalikan ke 10 dan 0", got.Stok, got.StokDipesan)
+	}
+}
+
+func TestCancelReleasesReservation(t *testing.T) {
+	repos := newTestRepos()
+	_, store := seedUser(t, repos, "penjual")
+	buyer, _ := seedUser(t, repos, "pembeli")
+	other, _ := seedUser(t, repos, "lain")
+	produk := seedProduct(t, repos, store.ID, 10, entities.ProductStatusActive)
+	s := NewTransactionService(repos, time.Hour, allowAll{})
+
+	trx, _, err := s.Checkout(buyer.ID, checkoutRequest(seedAddress(t, repos, buyer.ID), [2]int{int(produk.ID), 4}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.Cancel(other.ID, trx.ID)
+	assertStatus(t, err, http.StatusNotFound)
+
+	cancelled, err := s.Cancel(buyer.ID, trx.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cancelled.StatusBayar != entities.TrxStatusCancelled {
+		t.Errorf("status %s, seharusnya cancelled", cancelled.StatusBayar)
+	}
+	got, _ := repos.Products.FindByID(produk.ID)
+	if got.Stok != 10 || got.StokDipesan != 0 {
+		t.Errorf("stok %d dipesan %d, seharusnya 10 dan 0", got.Stok, got.StokDipesan)
+	}
+
+	_, err = s.Cancel(buyer.ID, trx.ID)
+	assertStatus(t, err, http.StatusBadRequest)
+}
+
+func TestPayConfirmsReservation(t *testing.T) {
+	repos := newTestRepos()
+	_, store := seedUser(t, repos, "penjual")
+	buyer, _ := seedUser(t, repos, "pembeli")
+	produk := seedProduct(t, repos, store.ID, 10, entities.ProductStatusActive)
+	s := NewTransactionService(repos, time.Hour, allowAll{})
+
+	trx, _, err := s.Checkout(buyer.ID, checkoutRequest(seedAddress(t, repos, buyer.ID), [2]int{int(produk.ID), 4}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	paid, err := s.Pay(trx.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if paid.StatusBayar != entities.TrxStatusPaid || paid.TanggalBayar == nil {
+		t.Errorf("status %s, seharusnya paid dengan tanggal bayar", paid.StatusBayar)
+	}
+	got, _ := repos.Products.FindByID(produk.ID)
+	if got.Stok != 6 || got.StokDipesan != 0 {
+		t.Errorf("stok %d dipesan %d, seharusnya 6 dan 0", got.Stok, got.StokDipesan)
+	}
+
+	_, err = s.Pay(trx.ID)
+	assertStatus(t, err, http.StatusBadRequest)
+}
+
+func TestPayAfterDeadlineReleasesStock(t *testing.T) {
+	repos := newTestRepos()
+	_, store := seedUser(t, repos, "penjual")
+	buyer, _ := seedUser(t, repos, "pembeli")
+	produk := seedProduct(t, repos, store.ID, 10, entities.ProductStatusActive)
+	s := NewTransactionService(repos, -time.Minute, allowAll{})
+
+	trx, _, err := s.Checkout(buyer.ID, checkoutRequest(seedAddress(t, repos, buyer.ID), [2]int{int(produk.ID), 4}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Pay(trx.ID)
+	assertStatus(t, err, http.StatusBadRequest)
+
+	got, _ := repos.Products.FindByID(produk.ID)
+	if got.Stok != 10 || got.StokDipesan != 0 {
+		t.Errorf("stok %d dipesan %d, seharusnya dikembalikan ke 10 dan 0", got.Stok, got.StokDipesan)
+	}
+}
+
+func TestReleaseNotifiesBackInStock(t *testing.T) {
+	cases := []struct {
+		name    string
+		window  time.Duration
+		release func(s *TransactionService, buyerID, trxID uint) error
+	}{
+		{"dibatalkan pembeli", time.Hour, func(s *TransactionService, buyerID, trxID uint) error {
+			_, err := s.Cancel(buyerID, trxID)
+			return err
+		}},
+		{"lewat batas bayar", -time.Minute, func(s *TransactionService, _, _ uint) error {
+			_, err := s.ExpireReservations()
+			return err
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repos := newTestRepos()
+			_, store := seedUser(t, repos, "penjual")
+			buyer, _ := seedUser(t, repos, "pembeli")
+			watcher, _ := seedUser(t, repos, "penunggu")
+			produk := seedProduct(t, repos, store.ID, 3, entities.ProductStatusActive)
+			s := NewTransactionService(repos, tc.window, allowAll{})
+
+			trx, _, err := s.Checkout(buyer.ID, checkoutRequest(seedAddress(t, repos, buyer.ID), [2]int{int(produk.ID), 3}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := NewProductService(repos).SubscribeRestock(watcher.ID, produk.ID); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := tc.release(s, buyer.ID, trx.ID); err != nil {
+				t.Fatal(err)
+			}
+			notifs, _ := repos.Notifications.ListForUser(watcher.ID, repository.NotificationFilter{Tipe: entities.NotificationBackInStock})
+			if len(notifs) != 1 {
+				t.Errorf("%d notifikasi stok tersedia, seharusnya 1", len(notifs))
+			}
+		})
+	}
+}
+
+func TestCheckoutRequiresOwnAddress(t *testing.T) {
+	repos := newTestRepos()
+	_, store := seedUser(t, repos, "penjual")
+	buyer, _ := seedUser(t, repos, "pembeli")
+	other, _ := seedUser(t, repos, "lain")
+	produk := seedProduct(t, repos, store.ID, 10, entities.ProductStatusActive)
+	s := NewTransactionService(repos, time.Hour, allowAll{})
+
+	for name, idAlamat := range map[string]uint{
+		"alamat tidak ada": 999,
+		"alamat user lain": seedAddress(t, repos, other.ID),
+		"tanpa alamat":     0,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := s.Checkout(buyer.ID, checkoutRequest(idAlamat, [2]int{int(produk.ID), 1}))
+			assertStatus(t, err, http.StatusBadRequest)
+		})
+	}
+
+	got, _ := repos.Products.FindByID(produk.ID)
+	if got.Stok != 10 {
+		t.Errorf("stok %d, seharusnya tetap 10", got.Stok)
+	}
+}
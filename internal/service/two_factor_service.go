@@ -0,0 +1,300 @@
+package service
+
+import (
+	"crypto/rand"
+	"errors"
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"go-evermos/pkg"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// recoveryCodeCount adalah jumlah kode cadangan yang dibuat sekaligus
+	recoveryCodeCount = 10
+	// challengeTTL adalah batas waktu memasukkan kode 2FA setelah password
+	// benar
+	challengeTTL = 5 * time.Minute
+	// maxChallengeAttempts adalah batas kode 2FA salah per challenge
+	maxChallengeAttempts = 5
+)
+
+// recoveryAlphabet adalah huruf kode cadangan (base32 huruf kecil, tanpa
+// 0/1/8/9 yang mirip huruf)
+const recoveryAlphabet = "abcdefghijklmnopqrstuvwxyz234567"
+
+// TwoFactorSetup adalah data yang ditampilkan saat menyiapkan 2FA. URI
+// ditampilkan sebagai QR code; Secret untuk dimasukkan manual.
+type TwoFactorSetup struct {
+	Secret string
+	URI    string
+}
+
+type TwoFactorStatus struct {
+	Enabled bool
+	// Required bernilai true jika user wajib memakai 2FA (role staf)
+	Required          bool
+	RecoveryCodesLeft int64
+}
+
+type TwoFactorService struct {
+	repos  *repository.Repositories
+	auth   *AuthService
+	issuer string
+	// requireAdmin mewajibkan 2FA untuk role staf dan route admin
+	requireAdmin bool
+}
+
+func NewTwoFactorService(repos *repository.Repositories, auth *AuthService, issuer string, requireAdmin bool) *TwoFactorService {
+	return &TwoFactorService{repos: repos, auth: auth, issuer: issuer, requireAdmin: requireAdmin}
+}
+
+func (s *TwoFactorService) Status(userID uint) (*TwoFactorStatus, error) {
+	user, err := s.repos.Users.FindByID(userID)
+	if err != nil {
+		return nil, orNotFound(err, "User tidak ditemukan")
+	}
+
+	staff, err := isStaff(s.repos, userID)
+	if err != nil {
+		return nil, err
+	}
+	status := &TwoFactorStatus{
+		Enabled:  twoFactorEnabled(user),
+		Required: s.requireAdmin && staff,
+	}
+	if status.Enabled {
+		if status.RecoveryCodesLeft, err = s.repos.RecoveryCodes.CountUnused(userID); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+// Setup membuat secret TOTP baru. 2FA belum aktif sampai Enable dipanggil
+// dengan kode dari aplikasi authenticator.
+func (s *TwoFactorService) Setup(userID uint) (*TwoFactorSetup, error) {
+	user, err := s.repos.Users.FindByID(userID)
+	if err != nil {
+		return nil, orNotFound(err, "User tidak ditemukan")
+	}
+	if twoFactorEnabled(user) {
+		return nil, badRequest("2FA sudah aktif")
+	}
+
+	secret, err := pkg.NewTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	user.TOTPSecret = &secret
+	if err := s.repos.Users.Save(user); err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorSetup{Secret: secret, URI: pkg.TOTPURI(s.issuer, user.Email, secret)}, nil
+}
+
+// Enable mengaktifkan 2FA setelah kode pertama dari authenticator cocok.
+// Semua sesi lama dicabut (sesi tersebut dibuka tanpa 2FA) dan perangkat ini
+// mendapat sesi baru. Kode cadangan hanya ditampilkan sekali di sini.
+func (s *TwoFactorService) Enable(userID uint, code string) (*TokenPair, []string, error) {
+	var (
+		pair  *TokenPair
+		codes []string
+	)
+	now := time.Now()
+
+	err := s.repos.Transaction(func(tx *repository.Repositories) error {
+		user, err := tx.Users.FindByID(userID)
+		if err != nil {
+			return orNotFound(err, "User tidak ditemukan")
+		}
+		if twoFactorEnabled(user) {
+			return badRequest("2FA sudah aktif")
+		}
+		if user.TOTPSecret == nil {
+			return badRequest("Siapkan 2FA terlebih dahulu")
+		}
+
+		step, ok := pkg.ValidateTOTP(*user.TOTPSecret, normalizeSecondFactor(code), now)
+		if !ok {
+			return badRequest("Kode 2FA salah")
+		}
+		advanced, err := tx.Users.AdvanceTOTPStep(user.ID, step)
+		if err != nil {
+			return err
+		}
+		if !advanced {
+			return badRequest("Kode 2FA sudah dipakai, tunggu kode berikutnya")
+		}
+		user.TOTPLastStep = step
+		user.TOTPEnabledAt = &now
+
+		if codes, err = replaceRecoveryCodes(tx, user.ID); err != nil {
+			return err
+		}
+		if err := revokeAllSessions(tx, user); err != nil {
+			return err
+		}
+		pair, err = s.auth.issue(tx, user, newSessionID())
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return pair, codes, nil
+}
+
+// Disable mematikan 2FA. Butuh password dan kode 2FA (atau kode cadangan)
+// supaya token yang dicuri saja tidak cukup.
+func (s *TwoFactorService) Disable(userID uint, password, code string) error {
+	now := time.Now()
+	return s.repos.Transaction(func(tx *repository.Repositories) error {
+		user, err := tx.Users.FindByID(userID)
+		if err != nil {
+			return orNotFound(err, "User tidak ditemukan")
+		}
+		if !twoFactorEnabled(user) {
+			return badRequest("2FA belum aktif")
+		}
+		if s.requireAdmin {
+			staff, err := isStaff(tx, user.ID)
+			if err != nil {
+				return err
+			}
+			if staff {
+				return forbidden("Admin wajib memakai 2FA")
+			}
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(user.KataSandi), []byte(password)); err != nil {
+			return badRequest("Password salah")
+		}
+		ok, err := verifySecondFactor(tx, user, code, now)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return badRequest("Kode 2FA salah")
+		}
+
+		user.TOTPSecret = nil
+		user.TOTPEnabledAt = nil
+		if err := tx.Users.Save(user); err != nil {
+			return err
+		}
+		return tx.RecoveryCodes.DeleteUser(user.ID)
+	})
+}
+
+// RegenerateRecoveryCodes membuat kode cadangan baru; kode lama tidak
+// berlaku lagi
+func (s *TwoFactorService) RegenerateRecoveryCodes(userID uint, code string) ([]string, error) {
+	var codes []string
+	now := time.Now()
+
+	err := s.repos.Transaction(func(tx *repository.Repositories) error {
+		user, err := tx.Users.FindByID(userID)
+		if err != nil {
+			return orNotFound(err, "User tidak ditemukan")
+		}
+		if !twoFactorEnabled(user) {
+			return badRequest("2FA belum aktif")
+		}
+		ok, err := verifySecondFactor(tx, user, code, now)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return badRequest("Kode 2FA salah")
+		}
+
+		codes, err = replaceRecoveryCodes(tx, user.ID)
+		return err
+	})
+	return codes, err
+}
+
+// Allow mewajibkan admin memakai 2FA sebelum mengakses route admin jika
+// kebijakan tersebut aktif
+func (s *TwoFactorService) Allow(userID uint, action Action) error {
+	if action != ActionAdmin || !s.requireAdmin {
+		return nil
+	}
+
+	user, err := s.repos.Users.FindByID(userID)
+	if err != nil {
+		return orNotFound(err, "User tidak ditemukan")
+	}
+	if !twoFactorEnabled(user) {
+		return forbidden("Admin wajib mengaktifkan 2FA terlebih dahulu")
+	}
+	return nil
+}
+
+func twoFactorEnabled(user *entities.User) bool {
+	return user.TOTPEnabledAt != nil && user.TOTPSecret != nil
+}
+
+// verifySecondFactor menerima kode TOTP atau kode cadangan. Kode TOTP yang
+// sama tidak bisa dipakai dua kali dan kode cadangan hangus setelah dipakai.
+func verifySecondFactor(tx *repository.Repositories, user *entities.User, code string, now time.Time) (bool, error) {
+	code = normalizeSecondFactor(code)
+	if user.TOTPSecret == nil || code == "" {
+		return false, nil
+	}
+
+	if step, ok := pkg.ValidateTOTP(*user.TOTPSecret, code, now); ok {
+		advanced, err := tx.Users.AdvanceTOTPStep(user.ID, step)
+		if advanced {
+			user.TOTPLastStep = step
+		}
+		return advanced, err
+	}
+
+	recovery, err := tx.RecoveryCodes.FindUnusedForUpdate(user.ID, hashToken(code))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, tx.RecoveryCodes.MarkUsed(recovery.ID, now)
+}
+
+// replaceRecoveryCodes mengganti semua kode cadangan user dan mengembalikan
+// kode barunya (format xxxxx-xxxxx)
+func replaceRecoveryCodes(tx *repository.Repositories, userID uint) ([]string, error) {
+	if err := tx.RecoveryCodes.DeleteUser(userID); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	rows := make([]entities.RecoveryCode, recoveryCodeCount)
+	for i := range codes {
+		raw := newRecoveryCode()
+		codes[i] = raw[:5] + "-" + raw[5:]
+		rows[i] = entities.RecoveryCode{IDUser: userID, CodeHash: hashToken(raw)}
+	}
+	if err := tx.RecoveryCodes.Create(rows); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func newRecoveryCode() string {
+	b := make([]byte, 10)
+	rand.Read(b)
+	for i := range b {
+		b[i] = recoveryAlphabet[int(b[i])%len(recoveryAlphabet)]
+	}
+	return string(b)
+}
+
+// normalizeSecondFactor membuang spasi dan tanda hubung supaya kode bisa
+// diketik seperti yang ditampilkan ("123 456", "abcde-fghij")
+func normalizeSecondFactor(code string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(code)))
+}
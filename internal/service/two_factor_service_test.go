@@ -0,0 +1,121 @@
+package service
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/pkg"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// enableTwoFactor mengaktifkan 2FA user memakai kode periode sekarang dan
+// mengembalikan secret, periode yang dipakai, dan kode cadangan
+func enableTwoFactor(t *testing.T, s *TwoFactorService, user *entities.User) (string, int64, []string) {
+	t.Helper()
+
+	setup, err := s.Setup(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	step := pkg.TOTPStep(time.Now())
+	_, codes, err := s.Enable(user.ID, totpCode(t, setup.Secret, step))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return setup.Secret, step, codes
+}
+
+func totpCode(t *testing.T, secret string, step int64) string {
+	t.Helper()
+
+	code, err := pkg.TOTPCode(secret, step)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
+
+// verifyLogin login dengan password lalu menukar challenge dengan kode 2FA
+func verifyLogin(t *testing.T, s *AuthService, user *entities.User, code string) error {
+	t.Helper()
+
+	res, err := s.Login(user.Email, testPassword, testClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Challenge == "" {
+		t.Fatal("login tidak meminta kode 2FA")
+	}
+	_, err = s.VerifyTwoFactor(res.Challenge, code, testClient)
+	return err
+}
+
+func TestTwoFactorRejectsReplayedCode(t *testing.T) {
+	repos := newTestRepos()
+	user := seedLoginUser(t, repos, "user")
+	auth := newTestAuth(repos, LoginLimits{})
+	s := NewTwoFactorService(repos, auth, "go-evermos", false)
+	secret, step, _ := enableTwoFactor(t, s, user)
+
+	// kode yang sudah dipakai saat Enable tidak bisa dipakai login
+	assertStatus(t, verifyLogin(t, auth, user, totpCode(t, secret, step)), http.StatusUnauthorized)
+
+	// kode periode berikutnya masih dalam toleransi dan berlaku sekali
+	if err := verifyLogin(t, auth, user, totpCode(t, secret, step+1)); err != nil {
+		t.Fatalf("kode periode berikutnya ditolak: %v", err)
+	}
+	assertStatus(t, verifyLogin(t, auth, user, totpCode(t, secret, step+1)), http.StatusUnauthorized)
+
+	// kode periode sebelumnya juga ditolak walau masih dalam toleransi
+	assertStatus(t, verifyLogin(t, auth, user, totpCode(t, secret, step-1)), http.StatusUnauthorized)
+
+	// begitu juga untuk aksi lain yang meminta kode 2FA
+	_, err := s.RegenerateRecoveryCodes(user.ID, totpCode(t, secret, step+1))
+	assertStatus(t, err, http.StatusBadRequest)
+}
+
+func TestRecoveryCodeSingleUse(t *testing.T) {
+	repos := newTestRepos()
+	user := seedLoginUser(t, repos, "user")
+	auth := newTestAuth(repos, LoginLimits{})
+	s := NewTwoFactorService(repos, auth, "go-evermos", false)
+	_, _, codes := enableTwoFactor(t, s, user)
+	if len(codes) != recoveryCodeCount {
+		t.Fatalf("%d kode cadangan, seharusnya %d", len(codes), recoveryCodeCount)
+	}
+
+	if err := verifyLogin(t, auth, user, codes[0]); err != nil {
+		t.Fatalf("kode cadangan ditolak: %v", err)
+	}
+	assertStatus(t, verifyLogin(t, auth, user, codes[0]), http.StatusUnauthorized)
+
+	status, err := s.Status(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.RecoveryCodesLeft != recoveryCodeCount-1 {
+		t.Errorf("sisa kode cadangan %d, seharusnya %d", status.RecoveryCodesLeft, recoveryCodeCount-1)
+	}
+
+	// penulisan dengan huruf besar dan tanda hubung tetap diterima
+	if err := verifyLogin(t, auth, user, "  "+upperDashed(codes[1])+" "); err != nil {
+		t.Fatalf("kode cadangan dengan format lain ditolak: %v", err)
+	}
+
+	// kode baru menggantikan semua kode lama
+	fresh, err := s.RegenerateRecoveryCodes(user.ID, codes[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertStatus(t, verifyLogin(t, auth, user, codes[3]), http.StatusUnauthorized)
+	if err := verifyLogin(t, auth, user, fresh[0]); err != nil {
+		t.Fatalf("kode cadangan baru ditolak: %v", err)
+	}
+}
+
+// upperDashed menulis kode dengan huruf besar dan tanda hubung di tengah
+func upperDashed(code string) string {
+	mid := len(code) / 2
+	return strings.ToUpper(code[:mid]) + "-" + strings.ToUpper(code[mid:])
+}
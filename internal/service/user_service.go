@@ -0,0 +1,232 @@
+package service
+
+import (
+	"go-evermos/internal/entities"
+	"go-evermos/internal/repository"
+	"go-evermos/pkg"
+	"log"
+	"slices"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type RegisterInput struct {
+	Nama         string `json:"nama"`
+	Email        string `json:"email"`
+	NoTelp       string `json:"no_telp"`
+	KataSandi    string `json:"kata_sandi"`
+	TanggalLahir string `json:"tanggal_lahir"`
+	JenisKelamin string `json:"jenis_kelamin"`
+	Tentang      string `json:"tentang"`
+	Pekerjaan    string `json:"pekerjaan"`
+	IDProvinsi   string `json:"id_provinsi"`
+	IDKota       string `json:"id_kota"`
+}
+
+type UpdateProfileInput struct {
+	Nama         string `json:"nama"`
+	NoTelp       string `json:"no_telp"`
+	JenisKelamin string `json:"jenis_kelamin"`
+	Tentang      string `json:"tentang"`
+	Pekerjaan    string `json:"pekerjaan"`
+	IDProvinsi   string `json:"id_provinsi"`
+	IDKota       string `json:"id_kota"`
+}
+
+type UserService struct {
+	repos         *repository.Repositories
+	verifications *VerificationService
+}
+
+func NewUserService(repos *repository.Repositories, verifications *VerificationService) *UserService {
+	return &UserService{repos: repos, verifications: verifications}
+}
+
+// Register membuat user baru sekaligus tokonya
+func (s *UserService) Register(input RegisterInput) (*entities.User, error) {
+	// No telp disimpan dalam format E.164 supaya cek unik tidak bisa
+	// diakali dengan penulisan berbeda (0811.. vs +62811..)
+	phone, err := normalizePhone(input.NoTelp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cek no_telp unik
+	if _, err := s.repos.Users.FindByPhone(phone); err == nil {
+		return nil, badRequest("No telepon sudah terdaftar")
+	}
+
+	// Cek email unik
+	if _, err := s.repos.Users.FindByEmail(input.Email); err == nil {
+		return nil, badRequest("Email sudah terdaftar")
+	}
+
+	// Hash password
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.KataSandi), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	// parse tanggal lahir
+	parsedDate, err := time.Parse("2006-01-02", input.TanggalLahir)
+	if err != nil {
+		return nil, badRequest("Format tanggal_lahir harus YYYY-MM-DD")
+	}
+
+	user := entities.User{
+		Nama:         input.Nama,
+		KataSandi:    string(hashedPassword),
+		Notelp:       phone,
+		TanggalLahir: parsedDate,
+		JenisKelamin: input.JenisKelamin,
+		Tentang:      &input.Tentang,
+		Pekerjaan:    input.Pekerjaan,
+		Email:        input.Email,
+		IDProvinsi:   input.IDProvinsi,
+		IDKota:       input.IDKota,
+	}
+
+	err = s.repos.Transaction(func(tx *repository.Repositories) error {
+		if err := tx.Users.Create(&user); err != nil {
+			return badRequest(err.Error())
+		}
+
+		// toko otomatis terbuat saat register
+		store := entities.Store{
+			IDUser:   user.ID,
+			NamaToko: &user.Nama,
+		}
+		return tx.Stores.Create(&store)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Kode verifikasi email langsung dikirim; jika gagal user bisa meminta
+	// ulang, jadi register tetap sukses
+	if err := s.verifications.Send(user.ID, entities.VerificationEmail); err != nil {
+		log.Printf("Gagal mengirim kode verifikasi ke user %d: %v", user.ID, err)
+	}
+	return &user, nil
+}
+
+func (s *UserService) Profile(userID uint) (*entities.User, error) {
+	user, err := s.repos.Users.FindByID(userID)
+	if err != nil {
+		return nil, orNotFound(err, "User tidak ditemukan")
+	}
+	return user, nil
+}
+
+func (s *UserService) UpdateProfile(userID uint, input UpdateProfileInput) (*entities.User, error) {
+	user, err := s.repos.Users.FindByID(userID)
+	if err != nil {
+		return nil, orNotFound(err, "User tidak ditemukan")
+	}
+
+	phone, err := normalizePhone(input.NoTelp)
+	if err != nil {
+		return nil, err
+	}
+	if phone != user.Notelp {
+		if other, err := s.repos.Users.FindByPhone(phone); err == nil && other.ID != user.ID {
+			return nil, badRequest("No telepon sudah terdaftar")
+		}
+		// No telp baru harus diverifikasi ulang
+		user.PhoneVerifiedAt = nil
+	}
+
+	// Update field
+	user.Nama = input.Nama
+	user.Notelp = phone
+	user.JenisKelamin = input.JenisKelamin
+	user.Tentang = &input.Tentang
+	user.Pekerjaan = input.Pekerjaan
+	user.IDProvinsi = input.IDProvinsi
+	user.IDKota = input.IDKota
+
+	if err := s.repos.Users.Save(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Ban memblokir akun user oleh actorID. Semua sesinya langsung dicabut dan
+// user tidak bisa login sampai blokir dibuka. Staf hanya bisa diblokir oleh
+// pemegang izin role:manage, dan admin aktif terakhir tidak bisa diblokir.
+func (s *UserService) Ban(actorID, id uint, alasan string) (*entities.User, error) {
+	if alasan == "" {
+		return nil, badRequest("Alasan wajib diisi")
+	}
+	if actorID == id {
+		return nil, badRequest("Tidak bisa memblokir akun sendiri")
+	}
+
+	var user *entities.User
+	err := s.repos.Transaction(func(tx *repository.Repositories) error {
+		var err error
+		user, err = tx.Users.FindByID(id)
+		if err != nil {
+			return orNotFound(err, "User tidak ditemukan")
+		}
+		if err := checkBanTarget(tx, actorID, user); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		user.BannedAt = &now
+		user.AlasanBan = &alasan
+		return revokeAllSessions(tx, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// checkBanTarget memastikan actorID boleh memblokir user
+func checkBanTarget(tx *repository.Repositories, actorID uint, user *entities.User) error {
+	staff, err := isStaff(tx, user.ID)
+	if err != nil || !staff {
+		return err
+	}
+
+	canManage, err := hasPermission(tx, actorID, pkg.PermRoleManage)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return forbidden("Hanya admin yang bisa memblokir staf")
+	}
+
+	granted, err := grantedRoles(tx, user.ID)
+	if err != nil {
+		return err
+	}
+	if user.BannedAt == nil && slices.Contains(granted, pkg.RoleAdmin) {
+		admins, err := tx.UserRoles.CountUsers(pkg.RoleAdmin)
+		if err != nil {
+			return err
+		}
+		if admins <= 1 {
+			return badRequest("Admin terakhir tidak bisa diblokir")
+		}
+	}
+	return nil
+}
+
+// Unban membuka blokir akun. User perlu login ulang.
+func (s *UserService) Unban(id uint) (*entities.User, error) {
+	user, err := s.repos.Users.FindByID(id)
+	if err != nil || user.BannedAt == nil {
+		return nil, orNotFound(repository.ErrNotFound, "User yang diblokir tidak ditemukan")
+	}
+
+	user.BannedAt = nil
+	user.AlasanBan = nil
+	if err := s.repos.Users.Save(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
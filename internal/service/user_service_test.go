@@ -0,0 +1,106 @@
+package service
+
+import (
+	"go-evermos/pkg"
+	"net/http"
+	"testing"
+)
+
+func grantRole(t *testing.T, s *RoleService, userID uint, role string) {
+	t.Helper()
+	if _, err := s.Grant(0, userID, role); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBanGuardsStaff(t *testing.T) {
+	repos := newTestRepos()
+	roles := NewRoleService(repos)
+	admin, _ := seedUser(t, repos, "admin")
+	admin2, _ := seedUser(t, repos, "admin2")
+	support, _ := seedUser(t, repos, "support")
+	finance, _ := seedUser(t, repos, "finance")
+	buyer, _ := seedUser(t, repos, "pembeli")
+	grantRole(t, roles, admin.ID, pkg.RoleAdmin)
+	grantRole(t, roles, admin2.ID, pkg.RoleAdmin)
+	grantRole(t, roles, support.ID, pkg.RoleSupport)
+	grantRole(t, roles, finance.ID, pkg.RoleFinance)
+	s := NewUserService(repos, nil)
+
+	cases := []struct {
+		name   string
+		actor  uint
+		target uint
+		status int
+	}{
+		{"blokir diri sendiri", support.ID, support.ID, http.StatusBadRequest},
+		{"support memblokir admin", support.ID, admin.ID, http.StatusForbidden},
+		{"support memblokir staf lain", support.ID, finance.ID, http.StatusForbidden},
+		{"admin memblokir diri sendiri", admin.ID, admin.ID, http.StatusBadRequest},
+		{"support memblokir pembeli", support.ID, buyer.ID, 0},
+		{"admin memblokir staf", admin.ID, finance.ID, 0},
+		{"admin memblokir admin lain", admin.ID, admin2.ID, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			user, err := s.Ban(tc.actor, tc.target, "alasan")
+			if tc.status != 0 {
+				assertStatus(t, err, tc.status)
+				got, _ := repos.Users.FindByID(tc.target)
+				if got.BannedAt != nil {
+					t.Error("user terblokir padahal ditolak")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if user.BannedAt == nil {
+				t.Error("user tidak terblokir")
+			}
+		})
+	}
+}
+
+func TestRevokeKeepsLastAdmin(t *testing.T) {
+	repos := newTestRepos()
+	roles := NewRoleService(repos)
+	admin, _ := seedUser(t, repos, "admin")
+	other, _ := seedUser(t, repos, "lain")
+	grantRole(t, roles, admin.ID, pkg.RoleAdmin)
+
+	_, err := roles.Revoke(admin.ID, pkg.RoleAdmin)
+	assertStatus(t, err, http.StatusBadRequest)
+
+	grantRole(t, roles, other.ID, pkg.RoleAdmin)
+	got, err := roles.Revoke(admin.ID, pkg.RoleAdmin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pkg.RolesAllow(got, pkg.PermRoleManage) {
+		t.Errorf("role %v masih punya role:manage", got)
+	}
+}
+
+func TestGrantRejectsImplicitRoles(t *testing.T) {
+	repos := newTestRepos()
+	roles := NewRoleService(repos)
+	user, _ := seedUser(t, repos, "user")
+
+	for _, role := range []string{pkg.RoleBuyer, pkg.RoleSeller, "superuser"} {
+		_, err := roles.Grant(0, user.ID, role)
+		assertStatus(t, err, http.StatusBadRequest)
+	}
+
+	got, err := roles.Grant(0, user.ID, pkg.RoleReseller)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pkg.RolesAllow(got, pkg.PermResellerPrice) {
+		t.Errorf("role %v tanpa izin harga reseller", got)
+	}
+	rows, _ := repos.UserRoles.ListByUser(user.ID)
+	if len(rows) != 1 || rows[0].IDPemberi != nil {
+		t.Errorf("role tersimpan %+v, seharusnya satu tanpa pemberi", rows)
+	}
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"go-evermos/internal/entities"
+	"go-evermos/internal/mail"
+	"go-evermos/internal/repository"
+	"go-evermos/internal/sms"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+)
+
+const (
+	// maxCodesPerHour membatasi jumlah kode yang dikirim per channel per jam
+	maxCodesPerHour = 5
+	// maxCodeAttempts adalah batas percobaan kode salah sebelum kode hangus
+	maxCodeAttempts = 5
+)
+
+// verificationPolicy adalah verifikasi yang wajib sebelum sebuah aksi.
+// Aksi yang tidak terdaftar (mis. melihat produk) selalu boleh.
+var verificationPolicy = map[Action]struct{ email, phone bool }{
+	ActionCheckout:  {email: true},
+	ActionOpenStore: {email: true, phone: true},
+}
+
+type VerificationService struct {
+	repos          *repository.Repositories
+	mailer         mail.Mailer
+	sms            sms.Sender
+	codeTTL        time.Duration
+	resendInterval time.Duration
+	// sending menghitung kode yang sedang dikirim di background
+	sending sync.WaitGroup
+}
+
+func NewVerificationService(repos *repository.Repositories, mailer mail.Mailer, sms sms.Sender, codeTTL, resendInterval time.Duration) *VerificationService {
+	return &VerificationService{repos: repos, mailer: mailer, sms: sms, codeTTL: codeTTL, resendInterval: resendInterval}
+}
+
+// Send membuat kode verifikasi baru dan mengirimkannya ke email atau no telp
+// user. Kode sebelumnya tidak berlaku lagi.
+func (s *VerificationService) Send(userID uint, channel string) error {
+	user, err := s.repos.Users.FindByID(userID)
+	if err != nil {
+		return orNotFound(err, "User tidak ditemukan")
+	}
+
+	target, verified, err := verificationTarget(user, channel)
+	if err != nil {
+		return err
+	}
+	if verified {
+		return badRequest(channelName(channel) + " sudah terverifikasi")
+	}
+
+	now := time.Now()
+	code := newVerificationCode()
+	err = s.repos.Transaction(func(tx *repository.Repositories) error {
+		if last, err := tx.Verifications.FindLatestForUpdate(userID, channel); err == nil {
+			if wait := last.CreatedAt.Add(s.resendInterval).Sub(now); wait > 0 {
+				return retryLater("Kode baru belum bisa diminta", wait)
+			}
+		} else if !errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+
+		count, err := tx.Verifications.CountSince(userID, channel, now.Add(-time.Hour))
+		if err != nil {
+			return err
+		}
+		if count >= maxCodesPerHour {
+			return tooManyRequests("Terlalu banyak permintaan kode, coba lagi nanti")
+		}
+
+		return tx.Verifications.Create(&entities.VerificationCode{
+			IDUser:    userID,
+			Channel:   channel,
+			Target:    target,
+			CodeHash:  hashCode(userID, channel, code),
+			ExpiresAt: now.Add(s.codeTTL),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	s.deliver(user, channel, target, code)
+	return nil
+}
+
+// Verify mencocokkan kode terakhir yang dikirim. Setelah maxCodeAttempts
+// kali salah, kode hangus dan user harus meminta kode baru.
+func (s *VerificationService) Verify(userID uint, channel, code string) error {
+	invalid := badRequest("Kode verifikasi tidak valid atau sudah kedaluwarsa")
+	now := time.Now()
+
+	// Percobaan yang salah tetap disimpan, jadi transaksi di-commit dan
+	// hasilnya dikembalikan lewat result
+	var result error
+	err := s.repos.Transaction(func(tx *repository.Repositories) error {
+		user, err := tx.Users.FindByID(userID)
+		if err != nil {
+			return orNotFound(err, "User tidak ditemukan")
+		}
+		target, verified, err := verificationTarget(user, channel)
+		if err != nil {
+			return err
+		}
+		if verified {
+			return badRequest(channelName(channel) + " sudah terverifikasi")
+		}
+
+		last, err := tx.Verifications.FindLatestForUpdate(userID, channel)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return invalid
+			}
+			return err
+		}
+		// Kode untuk alamat lama tidak berlaku setelah alamat diganti
+		if last.UsedAt != nil || now.After(last.ExpiresAt) || last.Target != target || last.Attempts >= maxCodeAttempts {
+			return invalid
+		}
+
+		if subtle.ConstantTimeCompare([]byte(last.CodeHash), []byte(hashCode(userID, channel, code))) != 1 {
+			last.Attempts++
+			result = invalid
+			return tx.Verifications.Save(last)
+		}
+
+		last.UsedAt = &now
+		if err := tx.Verifications.Save(last); err != nil {
+			return err
+		}
+		if channel == entities.VerificationEmail {
+			user.EmailVerifiedAt = &now
+		} else {
+			user.PhoneVerifiedAt = &now
+		}
+		return tx.Users.Save(user)
+	})
+	if err != nil {
+		return err
+	}
+	return result
+}
+
+// Allow menerapkan verificationPolicy
+func (s *VerificationService) Allow(userID uint, action Action) error {
+	required, ok := verificationPolicy[action]
+	if !ok {
+		return nil
+	}
+
+	user, err := s.repos.Users.FindByID(userID)
+	if err != nil {
+		return orNotFound(err, "User tidak ditemukan")
+	}
+	if required.email && user.EmailVerifiedAt == nil {
+		return forbidden("Verifikasi email terlebih dahulu")
+	}
+	if required.phone && user.PhoneVerifiedAt == nil {
+		return forbidden("Verifikasi no telepon terlebih dahulu")
+	}
+	return nil
+}
+
+// Wait menunggu kode yang sedang dikirim selesai. Dipanggil saat shutdown.
+func (s *VerificationService) Wait() {
+	s.sending.Wait()
+}
+
+// deliver mengirim kode di background supaya request tidak menunggu
+// server email/SMS
+func (s *VerificationService) deliver(user *entities.User, channel, target, code string) {
+	minutes := int(s.codeTTL.Minutes())
+
+	s.sending.Add(1)
+	go func() {
+		defer s.sending.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), mailTimeout)
+		defer cancel()
+
+		var err error
+		if channel == entities.VerificationEmail {
+			err = s.mailer.Send(ctx, mail.Message{
+				To:      target,
+				Subject: "Kode verifikasi email Evermos",
+				Body: fmt.Sprintf("Halo %s,\n\nKode verifikasi email kamu: %s\n\n"+
+					"Kode berlaku %d menit. Jangan berikan kode ini kepada siapa pun.\n", user.Nama, code, minutes),
+			})
+		} else {
+			err = s.sms.Send(ctx, target, fmt.Sprintf("Kode verifikasi Evermos: %s. Berlaku %d menit. Jangan berikan kode ini kepada siapa pun.", code, minutes))
+		}
+		if err != nil {
+			log.Printf("Gagal mengirim kode verifikasi %s ke user %d: %v", channel, user.ID, err)
+		}
+	}()
+}
+
+// verificationTarget mengembalikan alamat tujuan channel dan apakah alamat
+// tersebut sudah terverifikasi
+func verificationTarget(user *entities.User, channel string) (string, bool, error) {
+	switch channel {
+	case entities.VerificationEmail:
+		return user.Email, user.EmailVerifiedAt != nil, nil
+	case entities.VerificationPhone:
+		return user.Notelp, user.PhoneVerifiedAt != nil, nil
+	}
+	return "", false, notFound("Channel verifikasi tidak dikenal")
+}
+
+func channelName(channel string) string {
+	if channel == entities.VerificationEmail {
+		return "Email"
+	}
+	return "No telepon"
+}
+
+// newVerificationCode membuat kode 6 digit
+func newVerificationCode() string {
+	n, _ := rand.Int(rand.Reader, big.NewInt(1000000))
+	return fmt.Sprintf("%06d", n.Int64())
+}
+
+// hashCode mengikat kode ke user dan channel, supaya hash yang sama tidak
+// berlaku untuk user lain
+func hashCode(userID uint, channel, code string) string {
+	return hashToken(fmt.Sprintf("%d:%s:%s", userID, channel, code))
+}
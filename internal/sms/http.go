@@ -0,0 +1,52 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// HTTPSender mengirim SMS lewat gateway HTTP: POST JSON {"to", "text"} ke
+// URL dengan header Authorization Bearer Token. Respons selain 2xx dianggap
+// gagal.
+type HTTPSender struct {
+	URL   string
+	Token string
+	// Client kosong berarti http.DefaultClient
+	Client *http.Client
+}
+
+func (s *HTTPSender) Send(ctx context.Context, to, text string) error {
+	body, err := json.Marshal(map[string]string{"to": to, "text": text})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("gateway SMS membalas status %d", resp.StatusCode)
+	}
+	return nil
+}
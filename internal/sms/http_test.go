@@ -0,0 +1,34 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPSender(t *testing.T) {
+	var got map[string]string
+	var auth string
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	s := &HTTPSender{URL: srv.URL, Token: "rahasia"}
+	if err := s.Send(context.Background(), "+628123456789", "Kode verifikasi: 123456"); err != nil {
+		t.Fatal(err)
+	}
+	if got["to"] != "+628123456789" || got["text"] != "Kode verifikasi: 123456" || auth != "Bearer rahasia" {
+		t.Errorf("request %v dengan Authorization %q tidak sesuai", got, auth)
+	}
+
+	status = http.StatusBadGateway
+	if err := s.Send(context.Background(), "+628123456789", "x"); err == nil {
+		t.Error("status 502 seharusnya error")
+	}
+}
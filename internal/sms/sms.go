@@ -0,0 +1,22 @@
+// Package sms mengirim SMS transaksional (kode verifikasi).
+package sms
+
+import (
+	"context"
+	"log"
+)
+
+// Sender mengirim SMS ke satu nomor telepon
+type Sender interface {
+	Send(ctx context.Context, to, text string) error
+}
+
+// LogSender adalah stub untuk development dan test: SMS tidak dikirim,
+// hanya ditulis ke log. Tidak boleh dipakai di production karena kode
+// verifikasi ikut tercatat di log; pakai HTTPSender.
+type LogSender struct{}
+
+func (LogSender) Send(ctx context.Context, to, text string) error {
+	log.Printf("sms ke %s: %s", to, text)
+	return nil
+}
@@ -1,76 +1,216 @@
 package main
 
 import (
+    "context"
     "go-evermos/config"
-    "go-evermos/internal/entities"
+    "go-evermos/internal/dto"
     "go-evermos/internal/handler"
+    "go-evermos/internal/mail"
+    "go-evermos/internal/repository"
+    "go-evermos/internal/router"
+    "go-evermos/internal/service"
+    "go-evermos/internal/sms"
     "go-evermos/pkg"
+    "log"
+    "os"
+    "os/signal"
+    "sync"
+    "syscall"
+    "time"
 
     "github.com/gofiber/fiber/v2"
 )
 
 func main() {
-    // Init DB
-    config.InitDB()
-
-    // Auto migrate
-    config.DB.AutoMigrate(
-        &entities.User{},
-		&entities.Category{},
-		&entities.Store{},
-		&entities.Product{},
-		&entities.ProductLog{},
-		&entities.ProductPicture{},
-		&entities.Trx{},
-		&entities.TrxDetail{},
-		&entities.Address{},
-    )
-
-    app := fiber.New()
-
-    app.Get("/", func(c *fiber.Ctx) error {
-        return c.SendString("API Ecommerce Jalan")
+    if len(os.Args) > 1 && os.Args[1] == "migrate" {
+        runMigrate(os.Args[2:])
+        return
+    }
+    if len(os.Args) > 1 && os.Args[1] == "role" {
+        runRole(os.Args[2:])
+        return
+    }
+
+    cfg, err := config.Load()
+    if err != nil {
+        log.Fatal("Konfigurasi tidak valid:\n", err)
+    }
+
+    // ctx selesai saat menerima SIGINT/SIGTERM
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer stop()
+
+    // Init DB, dicoba ulang sampai DB_CONNECT_TIMEOUT
+    db, err := config.Connect(ctx, cfg.DB)
+    if err != nil {
+        log.Fatal("Gagal koneksi database:", err)
+    }
+    log.Println("Database connected (" + cfg.DB.Driver + ")")
+
+    // Terapkan migrasi yang belum jalan. Aman untuk beberapa instance
+    // sekaligus karena migrator memegang advisory lock.
+    migrator := newMigrator(db, cfg.DB.Driver)
+    done, err := migrator.Up(ctx)
+    if err != nil {
+        log.Fatal("Migrasi database gagal:", err)
+    }
+    for _, mig := range done {
+        log.Println("Migrasi diterapkan:", mig)
+    }
+
+    // Wiring repository -> service -> handler
+    sqlDB, err := db.DB()
+    if err != nil {
+        log.Fatal("Gagal ambil koneksi database:", err)
+    }
+    repos := repository.NewGorm(db)
+    tokens, err := newTokenManager(cfg.JWT)
+    if err != nil {
+        log.Fatal("Gagal memuat kunci JWT:", err)
+    }
+    mailer := newMailer(cfg.Mail)
+    verifications := service.NewVerificationService(repos, mailer, newSMSSender(cfg.SMS), cfg.Verification.CodeTTL, cfg.Verification.ResendInterval)
+    auth := service.NewAuthService(repos, tokens, cfg.JWT.RefreshTTL, service.LoginLimits{
+        MaxAttempts:   cfg.Login.MaxAttempts,
+        IPMaxAttempts: cfg.Login.IPMaxAttempts,
+        Lockout:       cfg.Login.Lockout,
     })
-    
-    // Public routes
-	app.Post("/register", handler.Register)
-	app.Post("/login", handler.Login)
-
-	// Protected routes
-	user := app.Group("/user", pkg.JWTMiddleware())
-	user.Get("/profile", handler.Profile)
-    user.Put("/profile", handler.UpdateProfile) //update profil
-
-    store := app.Group("/store", pkg.JWTMiddleware())
-    store.Get("/", handler.GetMyStore)
-    store.Put("/", handler.UpdateMyStore)
-
-    address := app.Group("/address", pkg.JWTMiddleware())
-    address.Post("/", handler.CreateAddress)
-    address.Get("/", handler.GetAddresses)
-    address.Put("/:id", handler.UpdateAddress)
-    address.Delete("/:id", handler.DeleteAddress)
-
-    category := app.Group("/categories", pkg.JWTMiddleware(), pkg.AdminOnly())
-    category.Post("/", handler.CreateCategory)
-    category.Get("/", handler.GetCategories)
-    category.Put("/:id", handler.UpdateCategory)
-    category.Delete("/:id", handler.DeleteCategory)
-
-    product := app.Group("/product", pkg.JWTMiddleware())
-    product.Post("/", handler.CreateProduct)
-    app.Post("/product", handler.CreateProduct)
-    app.Get("/products", handler.GetAllProducts)
-    app.Get("/product/:id", handler.GetProductByID)
-    app.Put("/product/:id", handler.UpdateProduct)
-    app.Delete("/product/:id", handler.DeleteProduct)
-
-    transaction := app.Group("/transactions", pkg.JWTMiddleware())
-    transaction.Post("/", handler.CreateTransaction)
-    transaction.Get("/", handler.GetUserTransactions)
-    transaction.Get("/:id", handler.GetUserTransactionByID)
-
-
-
-    app.Listen(":3000")
+    services := handler.Services{
+        Auth:           auth,
+        PasswordResets: service.NewPasswordResetService(repos, mailer, cfg.PasswordReset.TTL, cfg.PasswordReset.URL),
+        Users:          service.NewUserService(repos, verifications),
+        Stores:         service.NewStoreService(repos),
+        Addresses:      service.NewAddressService(repos),
+        Categories:     service.NewCategoryService(repos),
+        Products:       service.NewProductService(repos),
+        Imports:        service.NewImportService(repos),
+        Transactions:   service.NewTransactionService(repos, cfg.PaymentWindow, verifications),
+        Notifications:  service.NewNotificationService(repos),
+        Health:         service.NewHealthService(sqlDB, migrator, service.UploadDir),
+        Verifications:  verifications,
+        TwoFactor:      service.NewTwoFactorService(repos, auth, cfg.TwoFactor.Issuer, cfg.TwoFactor.RequireAdmin),
+        Roles:          service.NewRoleService(repos),
+    }
+
+    app := fiber.New(fiber.Config{
+        ErrorHandler: pkg.ErrorHandler,
+        // IP client (dipakai proteksi brute force login) hanya diambil dari
+        // X-Forwarded-For jika request datang dari proxy terpercaya
+        ProxyHeader:             proxyHeader(cfg.TrustedProxies),
+        EnableTrustedProxyCheck: len(cfg.TrustedProxies) > 0,
+        TrustedProxies:          cfg.TrustedProxies,
+    })
+    app.Use(pkg.RequestID())
+    app.Use(pkg.Recover())
+    app.Use(pkg.BlockFields(dto.SensitiveFields...))
+
+    // Daftarkan semua route; gagal start jika ada route yang auth-nya salah
+    deps := router.Deps{
+        Tokens:      tokens,
+        Revocations: services.Auth,
+        Stores:      services.Stores,
+        Policy:      service.AccountPolicies{services.Verifications, services.TwoFactor},
+        Roles:       services.Roles,
+    }
+    if err := router.Register(app, router.Routes(handler.New(services)), deps); err != nil {
+        log.Fatal("Route tidak valid:\n", err)
+    }
+
+    // Lepas stok transaksi yang tidak dibayar sampai batas waktu
+    var workers sync.WaitGroup
+    workers.Add(1)
+    go func() {
+        defer workers.Done()
+        services.Transactions.StartReservationWorker(ctx, time.Minute)
+    }()
+
+    serverErr := make(chan error, 1)
+    go func() {
+        serverErr <- app.Listen(cfg.Addr())
+    }()
+
+    exitCode := 0
+    select {
+    case err := <-serverErr:
+        log.Println("Server gagal berjalan:", err)
+        exitCode = 1
+    case <-ctx.Done():
+        log.Println("Mematikan server...")
+    }
+
+    // Tunggu request yang sedang berjalan, lalu hentikan worker sebelum
+    // koneksi database ditutup
+    if err := app.ShutdownWithTimeout(cfg.ShutdownTimeout); err != nil {
+        log.Println("Gagal menunggu request selesai:", err)
+    }
+    stop()
+    workers.Wait()
+    services.Imports.Wait()
+    services.PasswordResets.Wait()
+    services.Verifications.Wait()
+
+    if err := config.Close(db); err != nil {
+        log.Println("Gagal menutup koneksi database:", err)
+    }
+    log.Println("Server berhenti")
+    if exitCode != 0 {
+        os.Exit(exitCode)
+    }
+}
+
+// newTokenManager memakai private key jika diatur, selain itu JWT_SECRET (HS256)
+func newTokenManager(cfg config.JWTConfig) (*pkg.TokenManager, error) {
+    opts := pkg.TokenOptions{
+        TTL:      cfg.TTL,
+        Issuer:   cfg.Issuer,
+        Audience: cfg.Audience,
+        Leeway:   cfg.Leeway,
+    }
+    if !cfg.Asymmetric() {
+        return pkg.NewTokenManager(cfg.Secret, opts), nil
+    }
+
+    privateKey, err := os.ReadFile(cfg.PrivateKeyFile)
+    if err != nil {
+        return nil, err
+    }
+    var publicKeys [][]byte
+    for _, f := range cfg.PublicKeyFiles {
+        data, err := os.ReadFile(f)
+        if err != nil {
+            return nil, err
+        }
+        publicKeys = append(publicKeys, data)
+    }
+    return pkg.NewTokenManagerWithKeys(privateKey, publicKeys, opts)
+}
+
+// newMailer memilih implementasi Mailer sesuai MAIL_DRIVER
+func newMailer(cfg config.MailConfig) mail.Mailer {
+    if cfg.Driver == config.MailDriverSMTP {
+        return &mail.SMTPMailer{
+            Host: cfg.SMTPHost,
+            Port: cfg.SMTPPort,
+            User: cfg.SMTPUser,
+            Pass: cfg.SMTPPass,
+            From: cfg.From,
+        }
+    }
+    return &mail.FileMailer{Dir: cfg.Dir, From: cfg.From}
+}
+
+// newSMSSender memilih implementasi sms.Sender sesuai SMS_DRIVER
+func newSMSSender(cfg config.SMSConfig) sms.Sender {
+    if cfg.Driver == config.SMSDriverHTTP {
+        return &sms.HTTPSender{URL: cfg.URL, Token: cfg.Token}
+    }
+    return sms.LogSender{}
+}
+
+// proxyHeader mengembalikan header IP client jika ada proxy terpercaya
+func proxyHeader(trusted []string) string {
+    if len(trusted) == 0 {
+        return ""
+    }
+    return fiber.HeaderXForwardedFor
 }
@@ -1,54 +1,181 @@
 package pkg
 
 import (
-	"os"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 )
 
 type JWTClaim struct {
-	UserID uint `json:"user_id"`
-	IsAdmin bool `json:"is_admin"`
+	UserID  uint     `json:"user_id"`
+	StoreID uint     `json:"store_id,omitempty"`
+	Roles   []string `json:"roles,omitempty"`
+	// SessionID adalah family refresh token tempat access token ini
+	// diterbitkan
+	SessionID string `json:"sid,omitempty"`
+	// Version dibandingkan dengan User.TokenVersion untuk mencabut semua
+	// token user sekaligus
+	Version uint `json:"ver,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(userID uint, isAdmin bool) (string, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		secret = "defaultsecret" // fallback
+// TokenManager membuat dan memvalidasi token JWT. Kunci dan masa berlaku
+// diterima dari config, bukan dibaca dari env.
+type TokenManager struct {
+	method  jwt.SigningMethod
+	signKey any
+	// keyID dikirim di header kid; kosong untuk HS256
+	keyID string
+	// keys adalah kunci verifikasi per kid
+	keys map[string]verificationKey
+	jwks []JWK
+	opts TokenOptions
+}
+
+// TokenOptions mengatur isi dan validasi claim standar
+type TokenOptions struct {
+	// TTL adalah masa berlaku access token
+	TTL time.Duration
+	// Issuer (iss) dan Audience (aud) diisi saat membuat token dan wajib
+	// sama persis saat validasi
+	Issuer   string
+	Audience string
+	// Leeway adalah toleransi selisih jam antar server untuk exp, nbf dan iat
+	Leeway time.Duration
+}
+
+// NewTokenManager membuat TokenManager HS256 dengan satu shared secret
+func NewTokenManager(secret string, opts TokenOptions) *TokenManager {
+	key := []byte(secret)
+	return &TokenManager{
+		method:  jwt.SigningMethodHS256,
+		signKey: key,
+		keys:    map[string]verificationKey{"": {method: jwt.SigningMethodHS256, key: key}},
+		opts:    opts,
 	}
+}
+
+// TTL adalah masa berlaku access token
+func (m *TokenManager) TTL() time.Duration {
+	return m.opts.TTL
+}
+
+// TokenSubject adalah data user yang dimasukkan ke access token
+type TokenSubject struct {
+	UserID  uint
+	StoreID uint
+	// Roles adalah role user saat token diterbitkan. Hanya informasi untuk
+	// client; izin selalu diperiksa dari role terbaru di database.
+	Roles []string
+	// SessionID adalah family refresh token tempat token diterbitkan
+	SessionID string
+	// Version adalah User.TokenVersion saat token diterbitkan
+	Version uint
+}
 
-	// Expired 24 jam
-	expirationTime := time.Now().Add(24 * time.Hour)
+func (m *TokenManager) Generate(sub TokenSubject) (string, error) {
+	now := time.Now()
 
 	claims := &JWTClaim{
-		UserID:  userID,
-		IsAdmin: isAdmin,
+		UserID:    sub.UserID,
+		StoreID:   sub.StoreID,
+		Roles:     sub.Roles,
+		SessionID: sub.SessionID,
+		Version:   sub.Version,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			ID:        newTokenID(),
+			Issuer:    m.opts.Issuer,
+			Audience:  jwt.ClaimStrings{m.opts.Audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(m.opts.TTL)),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	return token.SignedString([]byte(secret))
+	return m.sign(claims)
 }
 
-// ValidateToken untuk parsing & validasi token
-func ValidateToken(tokenString string) (*JWTClaim, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		secret = "defaultsecret"
+func (m *TokenManager) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(m.method, claims)
+	if m.keyID != "" {
+		token.Header["kid"] = m.keyID
 	}
 
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaim{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(secret), nil
-	})
+	return token.SignedString(m.signKey)
+}
+
+// Validate memeriksa tanda tangan dan claim token. Algoritma dibatasi ke
+// algoritma kunci yang dikenal (header alg tidak dipercaya), lalu exp, nbf,
+// iat, iss, aud dan jti wajib ada dan sesuai.
+func (m *TokenManager) Validate(tokenString string) (*JWTClaim, error) {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(m.algorithms()),
+		// Claim divalidasi sendiri di verifyClaims supaya bisa memakai leeway
+		// dan mewajibkan claim yang di library bersifat opsional
+		jwt.WithoutClaimsValidation(),
+	)
 
-	if claims, ok := token.Claims.(*JWTClaim); ok && token.Valid {
-		return claims, nil
-	} else {
+	claims := &JWTClaim{}
+	token, err := parser.ParseWithClaims(tokenString, claims, m.verificationKeyFor)
+	if err != nil {
 		return nil, err
 	}
+	if !token.Valid {
+		return nil, errors.New("token tidak valid")
+	}
+	if err := m.verifyClaims(claims, time.Now()); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// verifyClaims memeriksa claim standar terhadap waktu now
+func (m *TokenManager) verifyClaims(c *JWTClaim, now time.Time) error {
+	leeway := m.opts.Leeway
+
+	switch {
+	case c.ExpiresAt == nil || c.IssuedAt == nil || c.NotBefore == nil:
+		return errors.New("claim exp, iat dan nbf wajib ada")
+	case !now.Before(c.ExpiresAt.Add(leeway)):
+		return errors.New("token sudah kedaluwarsa")
+	case now.Add(leeway).Before(c.NotBefore.Time):
+		return errors.New("token belum berlaku")
+	case now.Add(leeway).Before(c.IssuedAt.Time):
+		return errors.New("token diterbitkan di masa depan")
+	case c.ExpiresAt.Sub(c.IssuedAt.Time) > m.opts.TTL:
+		return errors.New("masa berlaku token melebihi TTL")
+	case c.Issuer != m.opts.Issuer:
+		return fmt.Errorf("issuer %q tidak diterima", c.Issuer)
+	case !slices.Equal(c.Audience, jwt.ClaimStrings{m.opts.Audience}):
+		return fmt.Errorf("audience %q tidak diterima", c.Audience)
+	case c.ID == "":
+		return errors.New("claim jti wajib ada")
+	case c.UserID == 0:
+		return errors.New("claim user_id wajib ada")
+	}
+	return nil
+}
+
+// algorithms adalah daftar algoritma yang diterima, diambil dari kunci
+// verifikasi yang terdaftar
+func (m *TokenManager) algorithms() []string {
+	var algs []string
+	for _, k := range m.keys {
+		if !slices.Contains(algs, k.method.Alg()) {
+			algs = append(algs, k.method.Alg())
+		}
+	}
+	return algs
+}
+
+// newTokenID membuat ID acak untuk claim jti
+func newTokenID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
 }
@@ -0,0 +1,185 @@
+package pkg
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// verificationKey adalah kunci untuk memverifikasi token dengan kid tertentu
+type verificationKey struct {
+	method jwt.SigningMethod
+	key    any
+}
+
+// JWK adalah satu kunci publik dalam format JSON Web Key (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv,omitempty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKSet adalah isi /.well-known/jwks.json
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// NewTokenManagerWithKeys membuat TokenManager yang menandatangani token
+// dengan private key (RSA untuk RS256, Ed25519 untuk EdDSA) dalam format PEM.
+// publicKeys adalah kunci publik tambahan yang masih diterima saat verifikasi,
+// misalnya kunci lama selama masa rotasi. kid setiap kunci adalah thumbprint
+// JWK-nya (RFC 7638), sehingga tidak perlu diatur manual.
+func NewTokenManagerWithKeys(privateKey []byte, publicKeys [][]byte, opts TokenOptions) (*TokenManager, error) {
+	signer, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("private key: %w", err)
+	}
+
+	m := &TokenManager{
+		signKey: signer,
+		keys:    map[string]verificationKey{},
+		opts:    opts,
+	}
+	m.keyID, m.method, err = m.addKey(signer.Public())
+	if err != nil {
+		return nil, fmt.Errorf("private key: %w", err)
+	}
+
+	for i, data := range publicKeys {
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("public key %d: bukan PEM", i+1)
+		}
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("public key %d: %w", i+1, err)
+		}
+		if _, _, err := m.addKey(pub); err != nil {
+			return nil, fmt.Errorf("public key %d: %w", i+1, err)
+		}
+	}
+	return m, nil
+}
+
+// addKey mendaftarkan kunci publik untuk verifikasi
+func (m *TokenManager) addKey(pub crypto.PublicKey) (string, jwt.SigningMethod, error) {
+	jwk, err := publicJWK(pub)
+	if err != nil {
+		return "", nil, err
+	}
+
+	method := jwt.GetSigningMethod(jwk.Alg)
+	m.keys[jwk.Kid] = verificationKey{method: method, key: pub}
+	m.jwks = append(m.jwks, jwk)
+	return jwk.Kid, method, nil
+}
+
+// JWKS mengembalikan semua kunci publik verifikasi. Kosong jika token
+// ditandatangani dengan secret HMAC, karena secret tidak boleh dibagikan.
+func (m *TokenManager) JWKS() JWKSet {
+	keys := make([]JWK, 0, len(m.jwks))
+	seen := map[string]bool{}
+	for _, k := range m.jwks {
+		if !seen[k.Kid] {
+			seen[k.Kid] = true
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Kid < keys[j].Kid })
+	return JWKSet{Keys: keys}
+}
+
+// verificationKeyFor memilih kunci berdasarkan header kid dan memastikan
+// algoritma token sama dengan algoritma kunci tersebut
+func (m *TokenManager) verificationKeyFor(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	k, ok := m.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("kid %q tidak dikenal", kid)
+	}
+	if token.Method.Alg() != k.method.Alg() {
+		return nil, fmt.Errorf("algoritma %s tidak diterima untuk kid %q", token.Method.Alg(), kid)
+	}
+	return k.key, nil
+}
+
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("bukan PEM")
+	}
+	if !strings.HasSuffix(block.Type, "PRIVATE KEY") {
+		return nil, fmt.Errorf("PEM %s bukan private key", block.Type)
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("tipe kunci %T tidak didukung", key)
+	}
+	return signer, nil
+}
+
+// publicJWK mengubah kunci publik menjadi JWK dengan kid berupa thumbprint
+// RFC 7638: SHA-256 dari member wajib dengan urutan key alfabetis
+func publicJWK(pub crypto.PublicKey) (JWK, error) {
+	b64 := base64.RawURLEncoding.EncodeToString
+
+	var (
+		jwk       JWK
+		canonical any
+	)
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() < 2048 {
+			return JWK{}, errors.New("kunci RSA minimal 2048 bit")
+		}
+		jwk = JWK{Kty: "RSA", Alg: jwt.SigningMethodRS256.Alg(), N: b64(k.N.Bytes()), E: b64(big.NewInt(int64(k.E)).Bytes())}
+		canonical = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	case ed25519.PublicKey:
+		jwk = JWK{Kty: "OKP", Crv: "Ed25519", Alg: jwt.SigningMethodEdDSA.Alg(), X: b64(k)}
+		canonical = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X}
+	default:
+		return JWK{}, fmt.Errorf("tipe kunci %T tidak didukung (pakai RSA atau Ed25519)", pub)
+	}
+
+	data, err := json.Marshal(canonical)
+	if err != nil {
+		return JWK{}, err
+	}
+	sum := sha256.Sum256(data)
+	jwk.Kid = b64(sum[:])
+	jwk.Use = "sig"
+	return jwk, nil
+}
@@ -0,0 +1,242 @@
+package pkg
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var testTokenOptions = TokenOptions{
+	TTL:      15 * time.Minute,
+	Issuer:   "go-evermos",
+	Audience: "go-evermos-api",
+	Leeway:   30 * time.Second,
+}
+
+// testManagers membuat TokenManager untuk setiap jenis kunci yang didukung
+func testManagers(t *testing.T) map[string]*TokenManager {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	managers := map[string]*TokenManager{
+		"HS256": NewTokenManager("secret-untuk-test", testTokenOptions),
+	}
+	for name, key := range map[string]crypto.Signer{"RS256": rsaKey, "EdDSA": edKey} {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pemKey := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+		m, err := NewTokenManagerWithKeys(pemKey, nil, testTokenOptions)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		managers[name] = m
+	}
+	return managers
+}
+
+// confusionKey adalah kunci yang diketahui penyerang untuk serangan
+// pertukaran algoritma: secret HMAC itu sendiri, atau kunci publik yang
+// dipakai sebagai secret HMAC
+func confusionKey(t *testing.T, m *TokenManager) []byte {
+	if key, ok := m.signKey.([]byte); ok {
+		return key
+	}
+	der, err := x509.MarshalPKIXPublicKey(m.signKey.(crypto.Signer).Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func TestValidateRejectsForgedAndMalformedTokens(t *testing.T) {
+	for alg, m := range testManagers(t) {
+		t.Run(alg, func(t *testing.T) {
+			testValidate(t, m)
+		})
+	}
+}
+
+func testValidate(t *testing.T, m *TokenManager) {
+	now := time.Now()
+	leeway := m.opts.Leeway
+
+	newClaims := func(mutate func(c *JWTClaim)) *JWTClaim {
+		c := &JWTClaim{
+			UserID: 1,
+			Roles:  []string{RoleBuyer},
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:        newTokenID(),
+				Issuer:    m.opts.Issuer,
+				Audience:  jwt.ClaimStrings{m.opts.Audience},
+				IssuedAt:  jwt.NewNumericDate(now),
+				NotBefore: jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(now.Add(m.opts.TTL)),
+			},
+		}
+		if mutate != nil {
+			mutate(c)
+		}
+		return c
+	}
+	// claims menandatangani claim valid yang diubah dengan kunci yang benar
+	claims := func(mutate func(c *JWTClaim)) func() (string, error) {
+		return func() (string, error) { return m.sign(newClaims(mutate)) }
+	}
+	// signWith menandatangani claim valid dengan algoritma, kid dan kunci lain
+	signWith := func(method jwt.SigningMethod, kid string, key any) func() (string, error) {
+		return func() (string, error) {
+			forged := jwt.NewWithClaims(method, newClaims(nil))
+			if kid != "" {
+				forged.Header["kid"] = kid
+			}
+			return forged.SignedString(key)
+		}
+	}
+	// tampered mengganti payload token valid dengan payload token admin
+	// tanpa mengubah tanda tangannya
+	tampered := func() (string, error) {
+		original, err := m.sign(newClaims(nil))
+		if err != nil {
+			return "", err
+		}
+		admin, err := m.sign(&JWTClaim{UserID: 2, Roles: []string{RoleAdmin}})
+		if err != nil {
+			return "", err
+		}
+		o, a := strings.Split(original, "."), strings.Split(admin, ".")
+		return o[0] + "." + a[1] + "." + o[2], nil
+	}
+	fixed := func(s string) func() (string, error) {
+		return func() (string, error) { return s, nil }
+	}
+
+	randomKey := make([]byte, 32)
+	rand.Read(randomKey)
+
+	cases := []struct {
+		name  string
+		token func() (string, error)
+		valid bool
+	}{
+		{"token valid", claims(nil), true},
+		{"kedaluwarsa dalam leeway", claims(func(c *JWTClaim) {
+			c.IssuedAt = jwt.NewNumericDate(now.Add(-m.opts.TTL))
+			c.NotBefore = c.IssuedAt
+			c.ExpiresAt = jwt.NewNumericDate(now.Add(-leeway / 2))
+		}), true},
+		{"alg none", signWith(jwt.SigningMethodNone, m.keyID, jwt.UnsafeAllowNoneSignatureType), false},
+		{"tanda tangan dengan kunci lain", signWith(jwt.SigningMethodHS256, m.keyID, randomKey), false},
+		{"algoritma ditukar (HS512 dengan kunci yang dikenal)", signWith(jwt.SigningMethodHS512, m.keyID, confusionKey(t, m)), false},
+		{"kid tidak dikenal", signWith(m.method, "kid-tidak-dikenal", m.signKey), false},
+		{"payload diubah", tampered, false},
+		{"kedaluwarsa", claims(func(c *JWTClaim) {
+			c.IssuedAt = jwt.NewNumericDate(now.Add(-m.opts.TTL - leeway - time.Minute))
+			c.NotBefore = c.IssuedAt
+			c.ExpiresAt = jwt.NewNumericDate(now.Add(-leeway - time.Minute))
+		}), false},
+		{"belum berlaku (nbf)", claims(func(c *JWTClaim) {
+			c.NotBefore = jwt.NewNumericDate(now.Add(leeway + time.Minute))
+		}), false},
+		{"diterbitkan di masa depan (iat)", claims(func(c *JWTClaim) {
+			c.IssuedAt = jwt.NewNumericDate(now.Add(leeway + time.Minute))
+		}), false},
+		{"masa berlaku melebihi TTL", claims(func(c *JWTClaim) {
+			c.ExpiresAt = jwt.NewNumericDate(now.Add(m.opts.TTL + time.Hour))
+		}), false},
+		{"tanpa exp", claims(func(c *JWTClaim) { c.ExpiresAt = nil }), false},
+		{"tanpa iat", claims(func(c *JWTClaim) { c.IssuedAt = nil }), false},
+		{"issuer lain", claims(func(c *JWTClaim) { c.Issuer = "issuer-lain" }), false},
+		{"tanpa issuer", claims(func(c *JWTClaim) { c.Issuer = "" }), false},
+		{"audience lain", claims(func(c *JWTClaim) { c.Audience = jwt.ClaimStrings{"audience-lain"} }), false},
+		{"audience tambahan", claims(func(c *JWTClaim) { c.Audience = append(c.Audience, "audience-lain") }), false},
+		{"tanpa jti", claims(func(c *JWTClaim) { c.ID = "" }), false},
+		{"tanpa user_id", claims(func(c *JWTClaim) { c.UserID = 0 }), false},
+		{"kosong", fixed(""), false},
+		{"bukan JWT", fixed("abc"), false},
+		{"dua bagian", fixed("a.b"), false},
+		{"empat bagian", fixed("a.b.c.d"), false},
+		{"bukan base64", fixed("!!.!!.!!"), false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			token, err := tc.token()
+			if err != nil {
+				t.Fatalf("gagal membuat token: %v", err)
+			}
+			_, err = m.Validate(token)
+			if tc.valid && err != nil {
+				t.Errorf("seharusnya diterima, ditolak: %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Error("seharusnya ditolak, diterima")
+			}
+		})
+	}
+}
+
+func TestGenerateRoundTrip(t *testing.T) {
+	for alg, m := range testManagers(t) {
+		t.Run(alg, func(t *testing.T) {
+			token, err := m.Generate(TokenSubject{UserID: 7, StoreID: 3, Roles: []string{RoleBuyer, RoleSeller}, SessionID: "sesi", Version: 2})
+			if err != nil {
+				t.Fatal(err)
+			}
+			claims, err := m.Validate(token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if claims.UserID != 7 || claims.StoreID != 3 || claims.SessionID != "sesi" || claims.Version != 2 {
+				t.Errorf("claim tidak sesuai: %+v", claims)
+			}
+		})
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	cases := []struct {
+		header string
+		token  string
+		ok     bool
+	}{
+		{"Bearer abc.def.ghi", "abc.def.ghi", true},
+		{"bearer abc.def.ghi", "abc.def.ghi", true},
+		{"", "", false},
+		{"abc.def.ghi", "", false},
+		{"Bearer", "", false},
+		{"Bearer ", "", false},
+		{"Bearerabc.def.ghi", "", false},
+		{"Basic dXNlcjpwYXNz", "", false},
+		{"Token abc.def.ghi", "", false},
+		{"Bearer  abc.def.ghi", "", false},
+		{"Bearer abc def", "", false},
+		{"Bearer abc\tdef", "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.header, func(t *testing.T) {
+			token, ok := BearerToken(tc.header)
+			if token != tc.token || ok != tc.ok {
+				t.Errorf("dapat (%q, %v), seharusnya (%q, %v)", token, ok, tc.token, tc.ok)
+			}
+		})
+	}
+}
@@ -1,41 +1,106 @@
 package pkg
 
 import (
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
-	"strings"
 )
 
-func JWTMiddleware() fiber.Handler {
-    return func(c *fiber.Ctx) error {
-        authHeader := c.Get("Authorization")
-        if authHeader == "" {
-            return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Missing or invalid token"})
-        }
-
-        // Format: Bearer <token>
-        tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-        claims, err := ValidateToken(tokenString)
-        if err != nil {
-            return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid or expired token"})
-        }
-
-        // Simpan data user ke context
-        c.Locals("user_id", claims.UserID)
-        c.Locals("is_admin", claims.IsAdmin)
-
-        return c.Next()
-    }
+// RevocationChecker memeriksa apakah token yang tanda tangannya valid sudah
+// dicabut (logout, ganti password, akun diblokir)
+type RevocationChecker interface {
+	Revoked(claims *JWTClaim) (bool, error)
+}
+
+func JWTMiddleware(tokens *TokenManager, revocations RevocationChecker) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		tokenString, ok := BearerToken(c.Get(fiber.HeaderAuthorization))
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Missing or invalid token"})
+		}
+
+		claims, err := tokens.Validate(tokenString)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid or expired token"})
+		}
+
+		revoked, err := revocations.Revoked(claims)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal memeriksa token"})
+		}
+		if revoked {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Token sudah dicabut, silakan login ulang"})
+		}
+
+		// Simpan data user ke context
+		SetPrincipal(c, principalFromClaims(claims))
+
+		return c.Next()
+	}
 }
 
-func AdminOnly() fiber.Handler {
+// OptionalJWTMiddleware mengisi principal jika ada token valid, tanpa menolak
+// request anonim. Dipakai route public yang tampilannya bergantung pada user.
+// Token yang sudah dicabut diperlakukan seperti request anonim.
+func OptionalJWTMiddleware(tokens *TokenManager, revocations RevocationChecker) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		isAdmin, ok := c.Locals("is_admin").(bool)
-		if !ok || !isAdmin {
+		tokenString, ok := BearerToken(c.Get(fiber.HeaderAuthorization))
+		if !ok {
+			return c.Next()
+		}
+
+		claims, err := tokens.Validate(tokenString)
+		if err == nil {
+			if revoked, err := revocations.Revoked(claims); err != nil || revoked {
+				return c.Next()
+			}
+			SetPrincipal(c, principalFromClaims(claims))
+		}
+
+		return c.Next()
+	}
+}
+
+// BearerToken mengambil token dari header "Authorization: Bearer <token>".
+// Skema lain, header tanpa skema, atau token yang memuat spasi ditolak.
+func BearerToken(header string) (string, bool) {
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	if token == "" || strings.ContainsAny(token, " \t") {
+		return "", false
+	}
+	return token, true
+}
+
+// RoleResolver mengambil role terbaru user dari database
+type RoleResolver interface {
+	CurrentRoles(userID uint) ([]string, error)
+}
+
+// RequirePermission menolak request jika user tidak punya izin perm. Role
+// dibaca ulang dari database, bukan dari token, supaya pemberian dan
+// pencabutan role langsung berlaku tanpa menunggu token kedaluwarsa.
+func RequirePermission(roles RoleResolver, perm Permission) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		p, ok := PrincipalFrom(c)
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Missing or invalid token"})
+		}
+
+		current, err := roles.CurrentRoles(p.UserID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal memeriksa izin"})
+		}
+		p.Roles = current
+		SetPrincipal(c, p)
+
+		if !p.Can(perm) {
 			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error": "Hanya admin yang boleh mengakses resource ini",
+				"error": "Anda tidak punya izin untuk mengakses resource ini",
 			})
 		}
 		return c.Next()
 	}
-}
\ No newline at end of file
+}
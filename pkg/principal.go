@@ -0,0 +1,72 @@
+package pkg
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// Principal adalah identitas user yang sudah terautentikasi lewat JWT
+type Principal struct {
+	UserID  uint
+	Roles   []string
+	StoreID uint
+	TokenID string
+	// SessionID adalah family refresh token (claim sid), kosong untuk
+	// token lama
+	SessionID string
+}
+
+// HasRole mengecek apakah principal punya role tertentu
+func (p Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+const principalKey = "principal"
+
+// SetPrincipal menyimpan principal ke context request
+func SetPrincipal(c *fiber.Ctx, p Principal) {
+	c.Locals(principalKey, p)
+}
+
+// PrincipalFrom mengambil principal dari context. ok bernilai false jika
+// request belum melewati JWTMiddleware atau token tidak valid.
+func PrincipalFrom(c *fiber.Ctx) (Principal, bool) {
+	p, ok := c.Locals(principalKey).(Principal)
+	return p, ok && p.UserID != 0
+}
+
+// RequirePrincipal sama seperti PrincipalFrom, tapi mengembalikan error 401
+// yang bisa langsung di-return dari handler
+func RequirePrincipal(c *fiber.Ctx) (Principal, error) {
+	p, ok := PrincipalFrom(c)
+	if !ok {
+		return p, fiber.NewError(fiber.StatusUnauthorized, "Missing or invalid token")
+	}
+	return p, nil
+}
+
+// CurrentUserID mengambil ID user login, error 401 jika tidak ada
+func CurrentUserID(c *fiber.Ctx) (uint, error) {
+	p, err := RequirePrincipal(c)
+	return p.UserID, err
+}
+
+// principalFromClaims mengubah claim JWT menjadi principal
+func principalFromClaims(claims *JWTClaim) Principal {
+	roles := claims.Roles
+	if len(roles) == 0 {
+		// token lama belum membawa roles
+		roles = implicitRoles(claims.StoreID != 0)
+	}
+	return Principal{
+		UserID:    claims.UserID,
+		Roles:     roles,
+		StoreID:   claims.StoreID,
+		TokenID:   claims.ID,
+		SessionID: claims.SessionID,
+	}
+}
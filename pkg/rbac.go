@@ -0,0 +1,87 @@
+package pkg
+
+import "slices"
+
+// Role yang dikenal aplikasi. Buyer dimiliki semua user dan seller dimiliki
+// pemilik toko; role lain diberikan admin dan disimpan di tabel UserRole.
+const (
+	RoleAdmin            = "admin"
+	RoleCatalogModerator = "catalog_moderator"
+	RoleSupport          = "support"
+	RoleFinance          = "finance"
+	RoleSeller           = "seller"
+	RoleReseller         = "reseller"
+	RoleBuyer            = "buyer"
+)
+
+// Permission adalah izin untuk satu kelompok aksi
+type Permission string
+
+const (
+	PermCategoryManage     Permission = "category:manage"
+	PermProductModerate    Permission = "product:moderate"
+	PermUserBan            Permission = "user:ban"
+	PermLoginAttemptRead   Permission = "login_attempt:read"
+	PermTransactionRead    Permission = "transaction:read_all"
+	PermTransactionConfirm Permission = "transaction:confirm"
+	PermRoleManage         Permission = "role:manage"
+	PermStoreManage        Permission = "store:manage"
+	PermCheckout           Permission = "checkout"
+	PermResellerPrice      Permission = "checkout:reseller_price"
+)
+
+// RolePermissions adalah izin setiap role. Admin memegang semua izin staf.
+var RolePermissions = map[string][]Permission{
+	RoleAdmin: {
+		PermCategoryManage, PermProductModerate, PermUserBan,
+		PermLoginAttemptRead, PermTransactionRead, PermTransactionConfirm, PermRoleManage,
+	},
+	RoleCatalogModerator: {PermCategoryManage, PermProductModerate},
+	RoleSupport:          {PermUserBan, PermLoginAttemptRead},
+	RoleFinance:          {PermTransactionRead, PermTransactionConfirm},
+	RoleSeller:           {PermStoreManage},
+	RoleReseller:         {PermCheckout, PermResellerPrice},
+	RoleBuyer:            {PermCheckout},
+}
+
+// GrantableRoles adalah role yang bisa diberikan dan dicabut admin
+var GrantableRoles = []string{RoleAdmin, RoleCatalogModerator, RoleSupport, RoleFinance, RoleReseller}
+
+// StaffRoles adalah role yang bisa mengakses route /admin
+var StaffRoles = []string{RoleAdmin, RoleCatalogModerator, RoleSupport, RoleFinance}
+
+// RolesAllow mengecek apakah salah satu role punya izin perm
+func RolesAllow(roles []string, perm Permission) bool {
+	for _, r := range roles {
+		if slices.Contains(RolePermissions[r], perm) {
+			return true
+		}
+	}
+	return false
+}
+
+// Can mengecek izin principal berdasarkan role yang dibawanya
+func (p Principal) Can(perm Permission) bool {
+	return RolesAllow(p.Roles, perm)
+}
+
+// implicitRoles adalah role yang tidak perlu diberikan: buyer untuk semua
+// user dan seller untuk pemilik toko
+func implicitRoles(hasStore bool) []string {
+	roles := []string{RoleBuyer}
+	if hasStore {
+		roles = append(roles, RoleSeller)
+	}
+	return roles
+}
+
+// UserRoles menggabungkan role implisit dengan role yang diberikan admin
+func UserRoles(hasStore bool, granted []string) []string {
+	roles := implicitRoles(hasStore)
+	for _, r := range granted {
+		if !slices.Contains(roles, r) {
+			roles = append(roles, r)
+		}
+	}
+	return roles
+}
@@ -0,0 +1,58 @@
+package pkg
+
+import (
+	"log"
+	"runtime/debug"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+)
+
+// RequestID memberi setiap request ID unik (header X-Request-ID)
+func RequestID() fiber.Handler {
+	return requestid.New()
+}
+
+// RequestIDFrom mengambil request ID dari context
+func RequestIDFrom(c *fiber.Ctx) string {
+	id, _ := c.Locals(requestid.ConfigDefault.ContextKey).(string)
+	return id
+}
+
+// Recover mengubah panic di handler menjadi response 500 berformat JSON
+// beserta request ID, supaya server tidak mati dan error bisa dilacak di log.
+func Recover() fiber.Handler {
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				requestID := RequestIDFrom(c)
+				log.Printf("panic [request_id=%s] %s %s: %v\n%s", requestID, c.Method(), c.Path(), r, debug.Stack())
+
+				err = c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":      "Terjadi kesalahan pada server",
+					"request_id": requestID,
+				})
+			}
+		}()
+		return c.Next()
+	}
+}
+
+// ErrorHandler menulis error yang di-return handler (termasuk *fiber.Error)
+// sebagai JSON dengan format yang sama seperti response error lainnya
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	code := fiber.StatusInternalServerError
+	message := "Terjadi kesalahan pada server"
+	if e, ok := err.(*fiber.Error); ok {
+		code = e.Code
+		message = e.Message
+	} else {
+		log.Printf("error [request_id=%s] %s %s: %v", RequestIDFrom(c), c.Method(), c.Path(), err)
+	}
+
+	body := fiber.Map{"error": message}
+	if code >= fiber.StatusInternalServerError {
+		body["request_id"] = RequestIDFrom(c)
+	}
+	return c.Status(code).JSON(body)
+}
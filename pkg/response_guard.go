@@ -0,0 +1,46 @@
+package pkg
+
+import (
+	"bytes"
+	"log"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BlockFields menolak response JSON yang memuat salah satu key terlarang
+// (mis. hash kata sandi) di level mana pun. Jaring pengaman terakhir jika ada
+// handler yang mengirim entity tanpa lewat DTO: response diganti 500 dan
+// kejadiannya dicatat di log, sehingga data sensitif tidak pernah keluar.
+func BlockFields(fields ...string) fiber.Handler {
+	// Cukup cari `"key":` di body. Tanda kutip di dalam nilai string selalu
+	// di-escape encoder JSON, jadi pola ini hanya cocok dengan key.
+	patterns := make([][]byte, 0, len(fields))
+	for _, f := range fields {
+		patterns = append(patterns, []byte(`"`+f+`":`))
+	}
+
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		if !strings.HasPrefix(string(c.Response().Header.ContentType()), fiber.MIMEApplicationJSON) {
+			return nil
+		}
+
+		body := c.Response().Body()
+		for i, p := range patterns {
+			if bytes.Contains(body, p) {
+				requestID := RequestIDFrom(c)
+				log.Printf("response diblokir [request_id=%s] %s %s: memuat field %q", requestID, c.Method(), c.Path(), fields[i])
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":      "Terjadi kesalahan pada server",
+					"request_id": requestID,
+				})
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,69 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Secret adalah secret SHA-1 dari lampiran B RFC 6238
+// ("12345678901234567890") dalam base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCodeRFC6238(t *testing.T) {
+	// vektor RFC memakai 8 digit; kode 6 digit adalah 6 digit terakhirnya
+	cases := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},          // 94287082
+		{1111111109, "081804"},  // 07081804
+		{1111111111, "050471"},  // 14050471
+		{1234567890, "005924"},  // 89005924
+		{2000000000, "279037"},  // 69279037
+		{20000000000, "353130"}, // 65353130
+	}
+	for _, tc := range cases {
+		got, err := TOTPCode(rfc6238Secret, TOTPStep(time.Unix(tc.unix, 0)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.code {
+			t.Errorf("T=%d: kode %s, seharusnya %s", tc.unix, got, tc.code)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := TOTPStep(now)
+	code := func(step int64) string {
+		c, err := TOTPCode(rfc6238Secret, step)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	for _, s := range []int64{step - totpSkew, step, step + totpSkew} {
+		got, ok := ValidateTOTP(rfc6238Secret, code(s), now)
+		if !ok || got != s {
+			t.Errorf("kode periode %d: (%d, %v), seharusnya (%d, true)", s, got, ok, s)
+		}
+	}
+	for _, s := range []int64{step - totpSkew - 1, step + totpSkew + 1} {
+		if _, ok := ValidateTOTP(rfc6238Secret, code(s), now); ok {
+			t.Errorf("kode periode %d di luar toleransi diterima", s)
+		}
+	}
+	if _, ok := ValidateTOTP(rfc6238Secret, " "+code(step)+" ", now); !ok {
+		t.Error("kode dengan spasi di tepi ditolak")
+	}
+	for _, c := range []string{"", "12345", "1234567"} {
+		if _, ok := ValidateTOTP(rfc6238Secret, c, now); ok {
+			t.Errorf("kode %q diterima", c)
+		}
+	}
+	if _, ok := ValidateTOTP("bukan base32!", code(step), now); ok {
+		t.Error("secret tidak valid diterima")
+	}
+}